@@ -0,0 +1,155 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+var (
+	annotate = flag.Bool("annotate", false, "print a unified diff adding a // glasgo: comment above each finding instead of the findings")
+	annotateWrite = flag.Bool("annotate-write", false, "add the -annotate comments to the files instead of printing the diff")
+)
+
+// annotateContext is how many unchanged lines surround each diff hunk
+const annotateContext = 3
+
+// annotatedFile is a file split into lines with comments to insert
+type annotatedFile struct {
+	display		string
+	lines		[]string
+	noEOL		bool
+	// comments to insert before each line, by 0 based line index
+	inserts		map[int][]string
+}
+
+// readAnnotated reads a file and works out the comment for each finding in it,
+// indented like the line the finding is on
+func readAnnotated(path string, findings []Finding) (*annotatedFile, error) {
+	src, err := os.ReadFile(path);
+	if err != nil {
+		return nil, err;
+	}
+	a := &annotatedFile{
+		display:	findings[0].Pos.Filename,
+		lines:		strings.Split(string(src), "\n"),
+		inserts:	make(map[int][]string),
+	}
+	if last := len(a.lines) - 1; a.lines[last] == "" {
+		a.lines = a.lines[:last];
+	} else {
+		a.noEOL = true;
+	}
+	for _, finding := range findings {
+		i := finding.Pos.Line - 1;
+		if i < 0 || i >= len(a.lines) {
+			return nil, fmt.Errorf("line %d is outside the file, it may have changed", finding.Pos.Line);
+		}
+		line := a.lines[i];
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))];
+		message := strings.Join(strings.Fields(finding.Message), " ");
+		a.inserts[i] = append(a.inserts[i], indent+"// glasgo: "+message);
+	}
+	return a, nil;
+}
+
+// writeDiff writes the unified diff adding a's comments
+// hunks closer than twice the context apart are merged
+func (a *annotatedFile) writeDiff(w io.Writer) {
+	var at []int;
+	for i := range a.inserts {
+		at = append(at, i);
+	}
+	sort.Ints(at);
+	fmt.Fprintf(w, "--- a/%s\n+++ b/%s\n", a.display, a.display);
+	added := 0;
+	for len(at) > 0 {
+		start := at[0] - annotateContext;
+		if start < 0 {
+			start = 0;
+		}
+		// end is exclusive so the context after a comment includes its line
+		end := at[0] + annotateContext + 1;
+		n := 1;
+		for n < len(at) && at[n]-annotateContext <= end {
+			end = at[n] + annotateContext + 1;
+			n++;
+		}
+		if end > len(a.lines) {
+			end = len(a.lines);
+		}
+		var hunk bytes.Buffer;
+		inserted := 0;
+		for i := start; i < end; i++ {
+			for _, comment := range a.inserts[i] {
+				fmt.Fprintf(&hunk, "+%s\n", comment);
+				inserted++;
+			}
+			fmt.Fprintf(&hunk, " %s\n", a.lines[i]);
+			if i == len(a.lines)-1 && a.noEOL {
+				hunk.WriteString("\\ No newline at end of file\n");
+			}
+		}
+		fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1+added, end-start+inserted);
+		hunk.WriteTo(w);
+		added += inserted;
+		at = at[n:];
+	}
+}
+
+// write adds a's comments to the file at path
+func (a *annotatedFile) write(path string) error {
+	info, err := os.Stat(path);
+	if err != nil {
+		return err;
+	}
+	var out []string;
+	for i, line := range a.lines {
+		out = append(out, a.inserts[i]...);
+		out = append(out, line);
+	}
+	src := strings.Join(out, "\n");
+	if !a.noEOL {
+		src += "\n";
+	}
+	return os.WriteFile(path, []byte(src), info.Mode());
+}
+
+// annotateFindings prints the diff adding a comment above each finding,
+// or with -annotate-write adds the comments to the files
+func annotateFindings(w io.Writer, findings []Finding) {
+	byFile := make(map[string][]Finding);
+	for _, finding := range findings {
+		byFile[finding.Path] = append(byFile[finding.Path], finding);
+	}
+	var paths []string;
+	for path := range byFile {
+		paths = append(paths, path);
+	}
+	sort.Strings(paths);
+	for _, path := range paths {
+		a, err := readAnnotated(path, byFile[path]);
+		if err != nil {
+			warnf("cannot annotate %s: %s", displayPath(path), err);
+			continue;
+		}
+		if !*annotateWrite {
+			a.writeDiff(w);
+			continue;
+		}
+		if err := a.write(path); err != nil {
+			warnf("cannot annotate %s: %s", displayPath(path), err);
+			continue;
+		}
+		if *outputFormat == "text" {
+			fmt.Printf("Annotated %s\n", a.display);
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns the lines "l1" to "ln"
+func numberedLines(n int) []string {
+	var lines []string;
+	for i := 1; i <= n; i++ {
+		lines = append(lines, fmt.Sprintf("l%d", i));
+	}
+	return lines;
+}
+
+func TestWriteDiff(t *testing.T) {
+	tests := []struct {
+		name	string
+		lines	int
+		noEOL	bool
+		inserts	map[int][]string
+		want	string
+	}{
+		{
+			"one comment",
+			10, false,
+			map[int][]string{4: {"// glasgo: a"}},
+			"@@ -2,7 +2,8 @@\n l2\n l3\n l4\n+// glasgo: a\n l5\n l6\n l7\n l8\n",
+		},
+		{
+			"two comments on a line",
+			3, false,
+			map[int][]string{0: {"// glasgo: a", "// glasgo: b"}},
+			"@@ -1,3 +1,5 @@\n+// glasgo: a\n+// glasgo: b\n l1\n l2\n l3\n",
+		},
+		{
+			"close comments share a hunk",
+			10, false,
+			map[int][]string{1: {"// glasgo: a"}, 6: {"// glasgo: b"}},
+			"@@ -1,10 +1,12 @@\n l1\n+// glasgo: a\n l2\n l3\n l4\n l5\n l6\n+// glasgo: b\n l7\n l8\n l9\n l10\n",
+		},
+		{
+			"far comments get their own hunks",
+			20, false,
+			map[int][]string{2: {"// glasgo: a"}, 12: {"// glasgo: b"}},
+			"@@ -1,6 +1,7 @@\n l1\n l2\n+// glasgo: a\n l3\n l4\n l5\n l6\n" +
+				"@@ -10,7 +11,8 @@\n l10\n l11\n l12\n+// glasgo: b\n l13\n l14\n l15\n l16\n",
+		},
+		{
+			"no newline at the end",
+			3, true,
+			map[int][]string{2: {"// glasgo: a"}},
+			"@@ -1,3 +1,4 @@\n l1\n l2\n+// glasgo: a\n l3\n\\ No newline at end of file\n",
+		},
+	}
+	for _, test := range tests {
+		a := &annotatedFile{display: "a.go", lines: numberedLines(test.lines), noEOL: test.noEOL, inserts: test.inserts};
+		var b bytes.Buffer;
+		a.writeDiff(&b);
+		want := "--- a/a.go\n+++ b/a.go\n" + test.want;
+		if b.String() != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, b.String(), want);
+		}
+	}
+}
+
+func TestReadAnnotated(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "a.go");
+	src := "package main\n\nfunc a() {\n\tif x {\n\t\ty()\n\t}\n}\n";
+	if err := os.WriteFile(name, []byte(src), 0644); err != nil {
+		t.Fatal(err);
+	}
+	finding := func(line int, message string) Finding {
+		return Finding{Pos: token.Position{Filename: "a.go", Line: line}, Message: message, Path: name};
+	};
+	a, err := readAnnotated(name, []Finding{finding(5, "y is\n  called"), finding(3, "a"), finding(5, "again")});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if len(a.lines) != 7 || a.noEOL {
+		t.Errorf("got %d lines, no newline %v, want 7 lines ending in a newline", len(a.lines), a.noEOL);
+	}
+	// comments are indented like their line and messages are put on one line
+	want := map[int][]string{
+		2:	{"// glasgo: a"},
+		4:	{"\t\t// glasgo: y is called", "\t\t// glasgo: again"},
+	}
+	if !reflect.DeepEqual(a.inserts, want) {
+		t.Errorf("got inserts %v, want %v", a.inserts, want);
+	}
+	if err := a.write(name); err != nil {
+		t.Fatal(err);
+	}
+	got, err := os.ReadFile(name);
+	if err != nil {
+		t.Fatal(err);
+	}
+	wantSrc := "package main\n\n// glasgo: a\nfunc a() {\n\tif x {\n\t\t// glasgo: y is called\n\t\t// glasgo: again\n\t\ty()\n\t}\n}\n";
+	if string(got) != wantSrc {
+		t.Errorf("got\n%s\nwant\n%s", got, wantSrc);
+	}
+	if _, err := readAnnotated(name, []Finding{finding(100, "gone")}); err == nil || !strings.Contains(err.Error(), "outside the file") {
+		t.Errorf("got error %v for a line outside the file", err);
+	}
+}
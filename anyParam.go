@@ -0,0 +1,73 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+var allowAnyParams = flag.Bool("allow-any-params", true, "allow interface{} parameters in exported functions, set to false to run the anyParam test")
+
+// logging style functions that take ...interface{} for good reason
+var anyVariadicAllow = []string{"Print", "Log", "Debug", "Info", "Warn", "Error", "Fatal", "Panic"}
+
+func init() {
+	registerOptIn("anyParam",
+		"this tests for exported functions with interface{} parameters",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return !*allowAnyParams;
+		},
+		anyParamCheck,
+		funcDecl)
+}
+
+// isEmptyInterface checks if a type is interface{} or any
+func isEmptyInterface(t types.Type) bool {
+	if t == nil {
+		return false;
+	}
+	iface, ok := t.Underlying().(*types.Interface);
+	return ok && iface.Empty();
+}
+
+// loggingFunc checks if a function name looks like a logging wrapper
+func loggingFunc(name string) bool {
+	if strings.HasSuffix(name, "f") {
+		return true;
+	}
+	for _, allowed := range anyVariadicAllow {
+		if strings.Contains(name, allowed) {
+			return true;
+		}
+	}
+	return false;
+}
+
+func anyParamCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || !fun.Name.IsExported() {
+		return;
+	}
+	for _, field := range fun.Type.Params.List {
+		typ := field.Type;
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			if loggingFunc(fun.Name.Name) {
+				continue;
+			}
+			typ = ellipsis.Elt;
+		}
+		if !isEmptyInterface(f.pkg.info.TypeOf(typ)) {
+			continue;
+		}
+		for _, name := range field.Names {
+			f.Reportf(name.Pos(), "exported function %s takes %s %s, prefer a concrete type", fun.Name.Name, name.Name, f.ASTString(field.Type));
+		}
+	}
+	return;
+}
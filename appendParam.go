@@ -0,0 +1,78 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+func init() {
+	register("appendParam",
+		"this tests for appending to a slice parameter which can write to the caller's backing array",
+		severityLow,
+		categoryStyle,
+		appendParamCheck,
+		callExpr)
+}
+
+// funcParam returns the parameter of fun, a *ast.FuncDecl or *ast.FuncLit,
+// that the expression x refers to or nil
+func funcParam(f *File, fun ast.Node, x ast.Expr) *ast.Ident {
+	var ftype *ast.FuncType;
+	switch fn := fun.(type) {
+	case *ast.FuncDecl:
+		ftype = fn.Type;
+	case *ast.FuncLit:
+		ftype = fn.Type;
+	default:
+		return nil;
+	}
+	id, ok := x.(*ast.Ident);
+	if !ok {
+		return nil;
+	}
+	obj := f.pkg.info.ObjectOf(id);
+	if obj == nil {
+		return nil;
+	}
+	for _, field := range ftype.Params.List {
+		for _, name := range field.Names {
+			if f.pkg.info.Defs[name] == obj {
+				return name;
+			}
+		}
+	}
+	return nil;
+}
+
+func appendParamCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) < 2 || !isBuiltin(f, call, "append") {
+		return;
+	}
+	// returning the result is the usual AppendX(dst []T, ...) []T idiom
+	if _, ok := f.parent().(*ast.ReturnStmt); ok {
+		return;
+	}
+	fun := f.enclosingFunc();
+	param := funcParam(f, fun, call.Args[0]);
+	if param == nil {
+		return;
+	}
+	t := f.pkg.info.TypeOf(param);
+	if t == nil {
+		return;
+	}
+	if _, ok := t.Underlying().(*types.Slice); !ok {
+		return;
+	}
+	// documented behaviour is fine
+	if decl, ok := fun.(*ast.FuncDecl); ok && decl.Doc != nil && strings.Contains(decl.Doc.Text(), param.Name) {
+		return;
+	}
+	f.Reportf(call.Pos(), "append to parameter %s may write to the caller's backing array: %s", param.Name, f.ASTString(call));
+	return;
+}
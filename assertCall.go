@@ -0,0 +1,38 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("assertCall",
+		"this tests for methods called directly on the result of a type assertion",
+		severityMedium,
+		categoryCorrectness,
+		assertCallCheck,
+		callExpr)
+}
+
+func assertCallCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return;
+	}
+	assert, ok := ast.Unparen(sel.X).(*ast.TypeAssertExpr);
+	if !ok || assert.Type == nil {
+		return;
+	}
+	if selection, ok := f.pkg.info.Selections[sel]; ok && selection.Kind() != types.MethodVal {
+		return;
+	}
+	f.Reportf(call.Pos(), "method called on a type assertion that panics on failure or may hold a nil pointer, check v, ok := %s first: %s", f.ASTString(assert), f.ASTString(call));
+	return;
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+var (
+	baselineFile = flag.String("baseline", "", "JSON file of accepted findings that are not reported, see -baseline-update")
+	baselineUpdate = flag.Bool("baseline-update", false, "rewrite the -baseline file, dropping findings that no longer occur and keeping the rest")
+	baselineAcceptNew = flag.Bool("baseline-accept-new", false, "with -baseline-update also add new findings to the baseline")
+)
+
+// fingerprint identifies a finding in a baseline
+// the line and column are left out so findings still match after code above them moves
+type fingerprint struct {
+	File	string
+	Checker	string
+	Message	string
+}
+
+// fingerprintOf returns the fingerprint of a finding
+func fingerprintOf(finding Finding) fingerprint {
+	return fingerprint{finding.Pos.Filename, finding.Checker, finding.Message};
+}
+
+// readBaseline reads the fingerprints in a baseline file
+// a missing file is an empty baseline when updating so one can be started
+func readBaseline(path string) (map[fingerprint]bool, error) {
+	baseline := make(map[fingerprint]bool);
+	data, err := os.ReadFile(path);
+	if errors.Is(err, fs.ErrNotExist) && *baselineUpdate {
+		return baseline, nil;
+	}
+	if err != nil {
+		return nil, err;
+	}
+	var fps []fingerprint;
+	if err := json.Unmarshal(data, &fps); err != nil {
+		return nil, err;
+	}
+	for _, fp := range fps {
+		baseline[fp] = true;
+	}
+	return baseline, nil;
+}
+
+// writeBaseline writes fingerprints to a baseline file in a stable order
+// so it diffs cleanly when checked in
+func writeBaseline(path string, baseline map[fingerprint]bool) error {
+	fps := make([]fingerprint, 0, len(baseline));
+	for fp := range baseline {
+		fps = append(fps, fp);
+	}
+	sort.Slice(fps, func(i, j int) bool {
+		a, b := fps[i], fps[j];
+		if a.File != b.File {
+			return a.File < b.File;
+		}
+		if a.Checker != b.Checker {
+			return a.Checker < b.Checker;
+		}
+		return a.Message < b.Message;
+	})
+	data, err := json.MarshalIndent(fps, "", "\t");
+	if err != nil {
+		return err;
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644);
+}
+
+// updateBaseline keeps the fingerprints of old that findings still have
+// and drops the fixed ones, new findings are only added with acceptNew
+func updateBaseline(old map[fingerprint]bool, findings []Finding, acceptNew bool) map[fingerprint]bool {
+	updated := make(map[fingerprint]bool);
+	for _, finding := range findings {
+		fp := fingerprintOf(finding);
+		if old[fp] || acceptNew {
+			updated[fp] = true;
+		}
+	}
+	return updated;
+}
+
+// filterBaseline drops the findings in the baseline
+func filterBaseline(findings []Finding, baseline map[fingerprint]bool) []Finding {
+	var kept []Finding;
+	for _, finding := range findings {
+		if !baseline[fingerprintOf(finding)] {
+			kept = append(kept, finding);
+		}
+	}
+	return kept;
+}
+
+// applyBaseline leaves the findings accepted in -baseline out of the report,
+// with -baseline-update the file is rewritten first
+func applyBaseline(findings []Finding) ([]Finding, error) {
+	if *baselineFile == "" {
+		return findings, nil;
+	}
+	baseline, err := readBaseline(*baselineFile);
+	if err != nil {
+		return nil, err;
+	}
+	if *baselineUpdate {
+		baseline = updateBaseline(baseline, findings, *baselineAcceptNew);
+		if err := writeBaseline(*baselineFile, baseline); err != nil {
+			return nil, err;
+		}
+	}
+	return filterBaseline(findings, baseline), nil;
+}
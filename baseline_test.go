@@ -0,0 +1,87 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateBaseline(t *testing.T) {
+	present := testFinding("a.go", 3, 1, "error", "error ignored f()");
+	moved := testFinding("a.go", 9, 1, "exit", "os.Exit in a library");
+	fixed := testFinding("b.go", 1, 1, "error", "error ignored g()");
+	added := testFinding("c.go", 2, 1, "tmpPath", "hardcoded /tmp path");
+	old := map[fingerprint]bool{
+		fingerprintOf(present):	true,
+		// a finding still matches after its line moves
+		fingerprintOf(testFinding("a.go", 5, 1, "exit", "os.Exit in a library")):	true,
+		fingerprintOf(fixed):	true,
+	}
+	findings := []Finding{present, moved, added};
+	tests := []struct {
+		name		string
+		acceptNew	bool
+		want		map[fingerprint]bool
+	}{
+		{"fixed removed and present kept", false, map[fingerprint]bool{fingerprintOf(present): true, fingerprintOf(moved): true}},
+		{"new accepted", true, map[fingerprint]bool{fingerprintOf(present): true, fingerprintOf(moved): true, fingerprintOf(added): true}},
+	}
+	for _, test := range tests {
+		if got := updateBaseline(old, findings, test.acceptNew); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want);
+		}
+	}
+	// new findings are still reported so they fail the run
+	if got := filterBaseline(findings, updateBaseline(old, findings, false)); !reflect.DeepEqual(got, []Finding{added}) {
+		t.Errorf("got %v reported, want only the new finding", got);
+	}
+}
+
+// TestApplyBaseline checks -baseline-update starts, rewrites and reads back a baseline file
+func TestApplyBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json");
+	first := []Finding{
+		testFinding("a.go", 3, 1, "error", "error ignored f()"),
+		testFinding("b.go", 1, 1, "error", "error ignored g()"),
+	};
+	setFlag(t, "baseline", path);
+	if _, err := applyBaseline(first); err == nil {
+		t.Error("no error for a missing baseline without -baseline-update");
+	}
+	setFlag(t, "baseline-update", "true");
+	setFlag(t, "baseline-accept-new", "true");
+	got, err := applyBaseline(first);
+	if err != nil {
+		t.Fatal(err);
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v reported after accepting them, want none", got);
+	}
+	// b.go is fixed and c.go is new
+	second := []Finding{first[0], testFinding("c.go", 2, 1, "exit", "os.Exit in a library")};
+	setFlag(t, "baseline-accept-new", "false");
+	got, err = applyBaseline(second);
+	if err != nil {
+		t.Fatal(err);
+	}
+	if !reflect.DeepEqual(got, second[1:]) {
+		t.Errorf("got %v reported, want only the new finding", got);
+	}
+	baseline, err := readBaseline(path);
+	if err != nil {
+		t.Fatal(err);
+	}
+	if want := map[fingerprint]bool{fingerprintOf(first[0]): true}; !reflect.DeepEqual(baseline, want) {
+		t.Errorf("baseline holds %v, want %v", baseline, want);
+	}
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	if _, err := applyBaseline(second); err == nil {
+		t.Error("no error for a corrupt baseline");
+	}
+}
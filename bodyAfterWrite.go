@@ -0,0 +1,101 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("bodyAfterWrite",
+		"this tests for HTTP handlers reading the request body after writing the response",
+		severityMedium,
+		categoryCorrectness,
+		bodyAfterWriteCheck,
+		funcDecl)
+}
+
+// handlerParams returns the http.ResponseWriter and *http.Request
+// parameters of a handler function or nil if it is not a handler
+func handlerParams(f *File, ftype *ast.FuncType) (w, r types.Object) {
+	for _, field := range ftype.Params.List {
+		t := f.pkg.info.TypeOf(field.Type);
+		if t == nil || len(field.Names) != 1 {
+			continue;
+		}
+		switch t.String() {
+		case "net/http.ResponseWriter":
+			w = f.pkg.info.Defs[field.Names[0]];
+		case "*net/http.Request":
+			r = f.pkg.info.Defs[field.Names[0]];
+		}
+	}
+	if w == nil || r == nil {
+		return nil, nil;
+	}
+	return w, r;
+}
+
+// writesResponse checks if a call writes to the response writer w
+// either as w.Write(...) and w.WriteHeader(...) or by passing w on
+// as in fmt.Fprintf(w, ...)
+func writesResponse(f *File, call *ast.CallExpr, w types.Object) bool {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && refersTo(f, sel.X, w) {
+		return sel.Sel.Name == "Write" || sel.Sel.Name == "WriteHeader";
+	}
+	return len(call.Args) > 0 && refersTo(f, call.Args[0], w);
+}
+
+// isReqBody checks for r.Body where r is the request
+func isReqBody(f *File, x ast.Expr, r types.Object) bool {
+	sel, ok := x.(*ast.SelectorExpr);
+	return ok && sel.Sel.Name == "Body" && refersTo(f, sel.X, r);
+}
+
+func bodyAfterWriteCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	w, r := handlerParams(f, fun.Type);
+	if w == nil {
+		return;
+	}
+	written := token.NoPos;
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr);
+		if !ok {
+			return true;
+		}
+		// closing the body is fine at any point
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Close" && isReqBody(f, sel.X, r) {
+			return false;
+		}
+		if written == token.NoPos && writesResponse(f, call, w) {
+			written = call.Pos();
+			return true;
+		}
+		if written == token.NoPos {
+			return true;
+		}
+		// r.Body.Read(...) or passing r.Body on as in io.ReadAll(r.Body)
+		reads := false;
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isReqBody(f, sel.X, r) {
+			reads = true;
+		}
+		for _, arg := range call.Args {
+			if isReqBody(f, arg, r) {
+				reads = true;
+			}
+		}
+		if reads {
+			f.Reportf(call.Pos(), "request body read after the response was written at %s: %s", f.loc(written), f.ASTString(call));
+			return false;
+		}
+		return true;
+	})
+	return;
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("builtinShadow",
+		"this tests for declarations shadowing built in names like len, error and string",
+		severityLow,
+		categoryStyle,
+		builtinShadowCheck,
+		assignStmt, funcDecl, genDecl)
+}
+
+// checkShadow reports id if it declares a new name that hides a built in
+func checkShadow(f *File, id *ast.Ident) {
+	if id == nil || id.Name == "_" || types.Universe.Lookup(id.Name) == nil {
+		return;
+	}
+	// x, len := ... only declares the names that are new
+	if f.pkg.info.Defs[id] == nil {
+		return;
+	}
+	f.Reportf(id.Pos(), "%s shadows the built in %s, use a different name", id.Name, id.Name);
+}
+
+// checkFieldShadows checks the names in a parameter or result list
+func checkFieldShadows(f *File, fields *ast.FieldList) {
+	if fields == nil {
+		return;
+	}
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			checkShadow(f, name);
+		}
+	}
+}
+
+func builtinShadowCheck(f *File, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		if n.Tok != token.DEFINE {
+			return;
+		}
+		for _, lhs := range n.Lhs {
+			if id, ok := lhs.(*ast.Ident); ok {
+				checkShadow(f, id);
+			}
+		}
+	case *ast.FuncDecl:
+		// method names live on their type and hide nothing
+		if n.Recv == nil {
+			checkShadow(f, n.Name);
+		}
+		checkFieldShadows(f, n.Recv);
+		checkFieldShadows(f, n.Type.Params);
+		checkFieldShadows(f, n.Type.Results);
+	case *ast.GenDecl:
+		for _, spec := range n.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					checkShadow(f, name);
+				}
+			case *ast.TypeSpec:
+				checkShadow(f, s.Name);
+			}
+		}
+	}
+	return;
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+var cacheDir = flag.String("cache", "", "directory to cache findings of unchanged packages in, e.g. $HOME/.cache/glasgo")
+
+// hashContents adds a file the findings depend on to a cache key
+func hashContents(h io.Writer, label, name string) error {
+	file, err := os.Open(name);
+	if err != nil {
+		return err;
+	}
+	defer file.Close();
+	fmt.Fprintf(h, "%s %s\n", label, name);
+	_, err = io.Copy(h, file);
+	return err;
+}
+
+// cacheKey hashes everything a package's findings depend on,
+// the file names and contents, the checkers being run, every flag setting,
+// the -rules-file rules, the -rules-dir plugins, the working directory and the glasgo binary itself
+func cacheKey(names []string) (string, error) {
+	h := sha256.New();
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			fmt.Fprintf(h, "exe %s %d %d\n", exe, info.Size(), info.ModTime().UnixNano());
+		}
+	}
+	var enabled []string
+	for name, on := range report {
+		if on {
+			enabled = append(enabled, name);
+		}
+	}
+	sort.Strings(enabled);
+	fmt.Fprintf(h, "checkers %v\n", enabled);
+	// reported paths, including those in messages and fix edits, can be relative to it
+	fmt.Fprintf(h, "dir %s\n", workDir);
+	// flags such as -paths and -exit-allow change what is reported
+	flag.VisitAll(func(fl *flag.Flag) {
+		fmt.Fprintf(h, "flag %s=%s\n", fl.Name, fl.Value);
+	});
+	// the flag only names the rules file, a rule can change under the same name
+	if *rulesFile != "" {
+		if err := hashContents(h, "rules", *rulesFile); err != nil {
+			return "", err;
+		}
+	}
+	// plugins can be rebuilt in place, so their contents are hashed too
+	if *rulesDir != "" {
+		plugins, err := filepath.Glob(filepath.Join(*rulesDir, "*.so"));
+		if err != nil {
+			return "", err;
+		}
+		for _, plugin := range plugins {
+			if err := hashContents(h, "plugin", plugin); err != nil {
+				return "", err;
+			}
+		}
+	}
+	for _, name := range names {
+		abs, err := filepath.Abs(name);
+		if err != nil {
+			return "", err;
+		}
+		file, err := os.Open(name);
+		if err != nil {
+			return "", err;
+		}
+		// the flag only names the -changed-from input, not its ranges
+		fmt.Fprintf(h, "file %s changed %v\n", abs, changedLines[abs]);
+		_, err = io.Copy(h, file);
+		file.Close();
+		if err != nil {
+			return "", err;
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil;
+}
+
+// cachePath is where the findings for a key are stored
+func cachePath(key string) string {
+	return filepath.Join(*cacheDir, key[:2], key+".json");
+}
+
+// loadCache returns the findings stored for key if there are any
+func loadCache(key string) ([]Finding, bool) {
+	data, err := os.ReadFile(cachePath(key));
+	if err != nil {
+		return nil, false;
+	}
+	var cached []Finding
+	if err := json.Unmarshal(data, &cached); err != nil {
+		// a corrupt entry is a miss, it is overwritten after checking
+		return nil, false;
+	}
+	return cached, true;
+}
+
+// storeCache saves the findings for key, failures only cost a re-check next time
+func storeCache(key string, cached []Finding) {
+	path := cachePath(key);
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		warnf("cannot create cache directory: %s", err);
+		return;
+	}
+	if cached == nil {
+		cached = []Finding{};
+	}
+	data, err := json.Marshal(cached);
+	if err != nil {
+		warnf("cannot encode cached findings: %s", err);
+		return;
+	}
+	// write then rename so a concurrent run never reads half an entry
+	tmp := path + ".tmp";
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		warnf("cannot write cache: %s", err);
+		return;
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		warnf("cannot write cache: %s", err);
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const cacheTestSrc = `package main
+
+func cached(x int) int {
+	x = x
+	return x
+}
+`
+
+// writeCacheTest writes a one file package to check and sets up an empty cache
+func writeCacheTest(t *testing.T) string {
+	t.Helper();
+	dir := t.TempDir();
+	name := filepath.Join(dir, "cached.go");
+	if err := os.WriteFile(name, []byte(cacheTestSrc), 0644); err != nil {
+		t.Fatal(err);
+	}
+	setFlag(t, "cache", filepath.Join(dir, "cache"));
+	return name;
+}
+
+func TestCacheHit(t *testing.T) {
+	name := writeCacheTest(t);
+	first := runCheckers(t, "selfAssign", name);
+	if len(first) != 1 {
+		t.Fatalf("got %d findings, want 1", len(first));
+	}
+	key, err := cacheKey([]string{name});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if _, ok := loadCache(key); !ok {
+		t.Fatal("findings were not cached");
+	}
+	// a hit returns the stored findings without checking the file again
+	storeCache(key, []Finding{{Checker: "stored"}});
+	second := runCheckers(t, "selfAssign", name);
+	if len(second) != 1 || second[0].Checker != "stored" {
+		t.Errorf("got %v, want the stored finding", second);
+	}
+}
+
+func TestCacheMiss(t *testing.T) {
+	tests := []struct {
+		name	string
+		change	func(t *testing.T, file string)
+	}{
+		{"file changed", func(t *testing.T, file string) {
+			if err := os.WriteFile(file, []byte(cacheTestSrc+"\n// changed\n"), 0644); err != nil {
+				t.Fatal(err);
+			}
+		}},
+		{"checkers changed", func(t *testing.T, file string) {
+			report["tautology"] = true;
+		}},
+		{"flag changed", func(t *testing.T, file string) {
+			setFlag(t, "paths", "absolute");
+		}},
+		{"working directory changed", func(t *testing.T, file string) {
+			old := workDir;
+			workDir = filepath.Dir(file);
+			t.Cleanup(func() {
+				workDir = old;
+			});
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := writeCacheTest(t);
+			runCheckers(t, "selfAssign", name);
+			before, err := cacheKey([]string{name});
+			if err != nil {
+				t.Fatal(err);
+			}
+			test.change(t, name);
+			after, err := cacheKey([]string{name});
+			if err != nil {
+				t.Fatal(err);
+			}
+			if before == after {
+				t.Fatal("cache key did not change");
+			}
+			if _, ok := loadCache(after); ok {
+				t.Error("cache hit after the change");
+			}
+		});
+	}
+}
+
+// TestCacheRulesFile checks editing a rule under the same -rules-file path
+// changes the cache key
+func TestCacheRulesFile(t *testing.T) {
+	name := writeCacheTest(t);
+	rules := filepath.Join(t.TempDir(), "rules.json");
+	if err := os.WriteFile(rules, []byte(`[{"name": "noSprint", "call": "fmt.Sprint", "message": "use strconv"}]`), 0644); err != nil {
+		t.Fatal(err);
+	}
+	setFlag(t, "rules-file", rules);
+	before, err := cacheKey([]string{name});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if err := os.WriteFile(rules, []byte(`[{"name": "noSprint", "call": "fmt.Sprintf", "message": "use strconv"}]`), 0644); err != nil {
+		t.Fatal(err);
+	}
+	after, err := cacheKey([]string{name});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if before == after {
+		t.Error("cache key did not change with the rules");
+	}
+}
+
+// TestCacheRulesDir checks rebuilding a -rules-dir plugin in place changes the cache key
+func TestCacheRulesDir(t *testing.T) {
+	name := writeCacheTest(t);
+	dir := t.TempDir();
+	plugin := filepath.Join(dir, "rules.so");
+	if err := os.WriteFile(plugin, []byte("first build"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	setFlag(t, "rules-dir", dir);
+	before, err := cacheKey([]string{name});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if err := os.WriteFile(plugin, []byte("second build"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	after, err := cacheKey([]string{name});
+	if err != nil {
+		t.Fatal(err);
+	}
+	if before == after {
+		t.Error("cache key did not change with the plugin");
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var changedFrom = flag.String("changed-from", "", "file of file:start-end line ranges or a unified diff, or - for stdin, to only report findings in")
+
+// lineRange is an inclusive range of changed lines in a file
+type lineRange struct {
+	start, end	int
+}
+
+// changedLines holds the changed line ranges by absolute file path
+// it is nil without -changed-from, when everything is reported
+var changedLines map[string][]lineRange
+
+// parseRange parses a line of -changed-from input,
+// file.go:10-20 or file.go:10 for a single line
+func parseRange(line string) (string, lineRange, error) {
+	i := strings.LastIndex(line, ":");
+	if i <= 0 {
+		return "", lineRange{}, fmt.Errorf("%q is not file:start-end", line);
+	}
+	name, spec := line[:i], line[i+1:];
+	startSpec, endSpec, ok := strings.Cut(spec, "-");
+	if !ok {
+		endSpec = startSpec;
+	}
+	start, err := strconv.Atoi(startSpec);
+	if err != nil {
+		return "", lineRange{}, fmt.Errorf("%q has a bad start line: %s", line, err);
+	}
+	end, err := strconv.Atoi(endSpec);
+	if err != nil {
+		return "", lineRange{}, fmt.Errorf("%q has a bad end line: %s", line, err);
+	}
+	if start < 1 || end < start {
+		return "", lineRange{}, fmt.Errorf("%q is not a range of lines", line);
+	}
+	return name, lineRange{start, end}, nil;
+}
+
+// hunk is the header of a unified diff hunk, @@ -a,b +c,d @@,
+// a missing count meaning 1 line
+type hunk struct {
+	oldCount	int
+	newStart	int
+	newCount	int
+}
+
+// lines returns the new file's lines c to c+d-1 in a hunk,
+// or false for a hunk that only removes lines, where d is 0
+func (h hunk) lines() (lineRange, bool) {
+	if h.newCount == 0 {
+		return lineRange{}, false;
+	}
+	return lineRange{h.newStart, h.newStart + h.newCount - 1}, true;
+}
+
+// parseSpan parses the a,b or c,d of a hunk header
+func parseSpan(spec string) (int, int, error) {
+	startSpec, countSpec, ok := strings.Cut(spec, ",");
+	if !ok {
+		countSpec = "1";
+	}
+	start, err := strconv.Atoi(startSpec);
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("bad start line %q", startSpec);
+	}
+	count, err := strconv.Atoi(countSpec);
+	if err != nil || count < 0 {
+		return 0, 0, fmt.Errorf("bad line count %q", countSpec);
+	}
+	return start, count, nil;
+}
+
+// parseHunk parses the header of a unified diff hunk
+func parseHunk(line string) (hunk, error) {
+	fields := strings.Fields(line);
+	if len(fields) < 4 || fields[0] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return hunk{}, fmt.Errorf("%q is not a hunk header", line);
+	}
+	_, oldCount, err := parseSpan(fields[1][1:]);
+	if err != nil {
+		return hunk{}, fmt.Errorf("%q has a %s", line, err);
+	}
+	newStart, newCount, err := parseSpan(fields[2][1:]);
+	if err != nil {
+		return hunk{}, fmt.Errorf("%q has a %s", line, err);
+	}
+	return hunk{oldCount, newStart, newCount}, nil;
+}
+
+// diffFile returns the new file named by a +++ line of a unified diff,
+// without git's b/ prefix, or "" for a deleted file
+func diffFile(line string) string {
+	name := strings.TrimPrefix(line, "+++ ");
+	// diff -u puts a tab and the time after the name
+	name, _, _ = strings.Cut(name, "\t");
+	if name == "/dev/null" {
+		return "";
+	}
+	return strings.TrimPrefix(name, "b/");
+}
+
+// parseChanges reads changed line ranges, one file:start-end per line, or a
+// unified diff such as git diff -U0 output, where the new side of each hunk
+// counts as changed, context lines included
+// blank lines and lines starting with # are skipped
+func parseChanges(r io.Reader) (map[string][]lineRange, error) {
+	changes := make(map[string][]lineRange);
+	add := func(name string, lines lineRange) {
+		if abs, err := filepath.Abs(name); err == nil {
+			name = abs;
+		}
+		changes[name] = append(changes[name], lines);
+	}
+	inDiff := false;
+	file := "";
+	// lines of the current hunk's body still to come, from each side
+	oldLeft, newLeft := 0, 0;
+	scanner := bufio.NewScanner(r);
+	for scanner.Scan() {
+		line := scanner.Text();
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				oldLeft--;
+			case strings.HasPrefix(line, "+"):
+				newLeft--;
+			case strings.HasPrefix(line, "\\"):
+				// \ No newline at end of file
+			default:
+				oldLeft--;
+				newLeft--;
+			}
+			continue;
+		}
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			inDiff = true;
+			file = diffFile(line);
+			continue;
+		case strings.HasPrefix(line, "@@ ") && inDiff:
+			h, err := parseHunk(line);
+			if err != nil {
+				return nil, err;
+			}
+			if lines, ok := h.lines(); ok && file != "" {
+				add(file, lines);
+			}
+			oldLeft, newLeft = h.oldCount, h.newCount;
+			continue;
+		case strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "--- "):
+			inDiff = true;
+			continue;
+		case inDiff:
+			// other headers of the diff, like index lines
+			continue;
+		}
+		line = strings.TrimSpace(line);
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue;
+		}
+		name, lines, err := parseRange(line);
+		if err != nil {
+			return nil, err;
+		}
+		add(name, lines);
+	}
+	return changes, scanner.Err();
+}
+
+// loadChangedLines reads the -changed-from ranges from a file or stdin
+func loadChangedLines() error {
+	if *changedFrom == "" {
+		return nil;
+	}
+	var r io.Reader = os.Stdin;
+	if *changedFrom != "-" {
+		file, err := os.Open(*changedFrom);
+		if err != nil {
+			return err;
+		}
+		defer file.Close();
+		r = file;
+	}
+	changes, err := parseChanges(r);
+	if err != nil {
+		return fmt.Errorf("-changed-from: %s", err);
+	}
+	changedLines = changes;
+	return nil;
+}
+
+// changed checks if line of the named file is in a -changed-from range
+func changed(name string, line int) bool {
+	if changedLines == nil {
+		return true;
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs;
+	}
+	for _, lines := range changedLines[name] {
+		if line >= lines.start && line <= lines.end {
+			return true;
+		}
+	}
+	return false;
+}
@@ -0,0 +1,209 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		line	string
+		name	string
+		lines	lineRange
+		err	bool
+	}{
+		{"a.go:10-20", "a.go", lineRange{10, 20}, false},
+		{"a.go:7", "a.go", lineRange{7, 7}, false},
+		{"dir/a.go:1-1", "dir/a.go", lineRange{1, 1}, false},
+		// only the last colon separates the lines
+		{"C:/src/a.go:3-4", "C:/src/a.go", lineRange{3, 4}, false},
+		{"a.go", "", lineRange{}, true},
+		{":3", "", lineRange{}, true},
+		{"a.go:x-3", "", lineRange{}, true},
+		{"a.go:3-x", "", lineRange{}, true},
+		{"a.go:0-3", "", lineRange{}, true},
+		{"a.go:5-3", "", lineRange{}, true},
+	}
+	for _, test := range tests {
+		name, lines, err := parseRange(test.line);
+		if (err != nil) != test.err {
+			t.Errorf("parseRange(%q) error = %v, want error %v", test.line, err, test.err);
+			continue;
+		}
+		if name != test.name || lines != test.lines {
+			t.Errorf("parseRange(%q) = %q, %v, want %q, %v", test.line, name, lines, test.name, test.lines);
+		}
+	}
+}
+
+func TestParseHunk(t *testing.T) {
+	tests := []struct {
+		line	string
+		hunk	hunk
+		lines	lineRange
+		ok	bool
+		err	bool
+	}{
+		{"@@ -1,3 +1,4 @@", hunk{3, 1, 4}, lineRange{1, 4}, true, false},
+		{"@@ -10,2 +12,5 @@ func main() {", hunk{2, 12, 5}, lineRange{12, 16}, true, false},
+		// a missing count is one line
+		{"@@ -3 +3 @@", hunk{1, 3, 1}, lineRange{3, 3}, true, false},
+		{"@@ -3,0 +4 @@", hunk{0, 4, 1}, lineRange{4, 4}, true, false},
+		// a hunk only removing lines has none on the new side
+		{"@@ -5,2 +4,0 @@", hunk{2, 4, 0}, lineRange{}, false, false},
+		{"@@ -1,3 1,4 @@", hunk{}, lineRange{}, false, true},
+		{"@@ 1,3 +1,4 @@", hunk{}, lineRange{}, false, true},
+		{"@@ -1,x +1,4 @@", hunk{}, lineRange{}, false, true},
+		{"@@ -1,3 +x,4 @@", hunk{}, lineRange{}, false, true},
+		{"@@ -1,3 +1,x @@", hunk{}, lineRange{}, false, true},
+		{"@@ -1,3 +1,-1 @@", hunk{}, lineRange{}, false, true},
+		{"@@ -1,3", hunk{}, lineRange{}, false, true},
+	}
+	for _, test := range tests {
+		h, err := parseHunk(test.line);
+		if (err != nil) != test.err {
+			t.Errorf("parseHunk(%q) error = %v, want error %v", test.line, err, test.err);
+			continue;
+		}
+		if h != test.hunk {
+			t.Errorf("parseHunk(%q) = %v, want %v", test.line, h, test.hunk);
+		}
+		if lines, ok := h.lines(); lines != test.lines || ok != test.ok {
+			t.Errorf("parseHunk(%q).lines() = %v, %v, want %v, %v", test.line, lines, ok, test.lines, test.ok);
+		}
+	}
+}
+
+// absChanges makes the names of changed line ranges absolute like parseChanges does
+func absChanges(t *testing.T, changes map[string][]lineRange) map[string][]lineRange {
+	abs := make(map[string][]lineRange);
+	for name, lines := range changes {
+		path, err := filepath.Abs(name);
+		if err != nil {
+			t.Fatal(err);
+		}
+		abs[path] = lines;
+	}
+	return abs;
+}
+
+func TestParseChanges(t *testing.T) {
+	tests := []struct {
+		name	string
+		input	string
+		want	map[string][]lineRange
+	}{
+		{
+			"ranges",
+			"# from CI\na.go:1-3\n\n  b.go:7  \na.go:10-12\n",
+			map[string][]lineRange{"a.go": {{1, 3}, {10, 12}}, "b.go": {{7, 7}}},
+		},
+		{
+			"git diff",
+			`diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -3,0 +4,2 @@ import (
++	"os"
++	"strings"
+@@ -20 +22 @@ func main() {
+-	x = 1
++	x = 2
+@@ -30,2 +31,0 @@ func main() {
+-	a()
+-	b()
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package main
+-
+diff --git a/c.go b/c.go
+--- a/c.go
++++ b/c.go
+@@ -1 +1 @@
+--- comment
++++ comment
+@@ -8,0 +9,2 @@
++a()
++b()
+`,
+			map[string][]lineRange{"a.go": {{4, 5}, {22, 22}}, "c.go": {{1, 1}, {9, 10}}},
+		},
+		{
+			"diff -u",
+			"--- a.go\t2024-01-01 00:00:00\n+++ a.go\t2024-01-02 00:00:00\n@@ -1,4 +1,5 @@\n package main\n+\n",
+			map[string][]lineRange{"a.go": {{1, 5}}},
+		},
+	}
+	for _, test := range tests {
+		got, err := parseChanges(strings.NewReader(test.input));
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err);
+			continue;
+		}
+		if want := absChanges(t, test.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, want);
+		}
+	}
+	if _, err := parseChanges(strings.NewReader("a.go:1-3\nnot a range\n")); err == nil {
+		t.Error("no error for a bad range");
+	}
+}
+
+func TestChanged(t *testing.T) {
+	saved := changedLines;
+	defer func() {
+		changedLines = saved;
+	}();
+	changedLines = nil;
+	if !changed("a.go", 100) {
+		t.Error("without -changed-from every line is changed");
+	}
+	changedLines = absChanges(t, map[string][]lineRange{"a.go": {{3, 5}, {9, 9}}});
+	tests := []struct {
+		name	string
+		line	int
+		want	bool
+	}{
+		{"a.go", 2, false},
+		{"a.go", 3, true},
+		{"a.go", 5, true},
+		{"a.go", 6, false},
+		{"a.go", 9, true},
+		{"./a.go", 4, true},
+		{"b.go", 4, false},
+	}
+	for _, test := range tests {
+		if got := changed(test.name, test.line); got != test.want {
+			t.Errorf("changed(%q, %d) = %v, want %v", test.name, test.line, got, test.want);
+		}
+	}
+}
+
+// TestChangedFindings checks a finding in a changed range is kept
+// and one outside is dropped
+func TestChangedFindings(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "changed.go");
+	src := "package main\n\nfunc changedLines(x, y int) int {\n\tx = x\n\ty = y\n\treturn x + y\n}\n";
+	if err := os.WriteFile(name, []byte(src), 0644); err != nil {
+		t.Fatal(err);
+	}
+	saved := changedLines;
+	defer func() {
+		changedLines = saved;
+	}();
+	changedLines = map[string][]lineRange{name: {{5, 6}}};
+	found := runCheckers(t, "selfAssign", name);
+	if len(found) != 1 || found[0].Pos.Line != 5 {
+		t.Errorf("got %v, want only the finding on line 5", found);
+	}
+}
@@ -4,15 +4,21 @@ package main
 
 import (
 	"go/ast"
+	"go/types"
 )
 
 func init() {
 	register("closeCheck",
-		"this tests if things with .Close() method have .Close() actually called on them",
+		"this tests for files opened and never closed or handed off by the function opening them",
+		severityMedium,
+		categoryCorrectness,
 		closeCheck,
-		funcDecl)
+		assignStmt,
+		exprStmt)
 }
 
+// opensFile checks if x is a call opening a file, like os.Open, os.Create
+// or a helper of the package returning an *os.File and an error
 func opensFile(f *File, x ast.Expr) bool {
 	/*
 	if(f.pkg.info.TypeOf(x) == nil) {
@@ -33,80 +39,131 @@ func opensFile(f *File, x ast.Expr) bool {
 	return false;
 }
 
-// closesFile checks the remaining statements in a function body for a .Close() method
-func closesFile(f *File, stmts []ast.Stmt) bool {
-	for _, stmt := range stmts {
-		switch expr := stmt.(type) {
-		case *ast.AssignStmt:
-			rhs := expr.Rhs;
-			for _, x := range rhs {
-				name, err := getFullFuncName(x);
-				if err != nil {
-					warnf("issue, %v", err);
-				}
-				if(name == "file/Close") {
-					return true
-				}
-			}
-		case *ast.ExprStmt:
-			name, err := getFullFuncName(expr.X);
-			if err != nil {
-				warnf("issue, %v", err);
-			}
-			if(name == "file/Close") {
-				return true
+// takesFile checks if a call may take over closing a file passed to it,
+// functions from other packages like io.Copy and bufio.NewReader don't
+// but functions of the package being checked and function values might
+func takesFile(f *File, call *ast.CallExpr) bool {
+	var obj types.Object;
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.SelectorExpr:
+		obj = f.pkg.info.ObjectOf(fun.Sel);
+	case *ast.Ident:
+		obj = f.pkg.info.ObjectOf(fun);
+	}
+	if _, ok := obj.(*types.Func); !ok || obj.Pkg() == nil {
+		return true;
+	}
+	return obj.Pkg() == f.pkg.typePkg;
+}
+
+// namedResult checks if obj is one of the named results of fun
+// so a bare return hands it to the caller
+func namedResult(f *File, fun ast.Node, obj types.Object) bool {
+	var typ *ast.FuncType;
+	switch fun := fun.(type) {
+	case *ast.FuncDecl:
+		typ = fun.Type;
+	case *ast.FuncLit:
+		typ = fun.Type;
+	}
+	if typ == nil || typ.Results == nil {
+		return false;
+	}
+	for _, field := range typ.Results.List {
+		for _, name := range field.Names {
+			if f.pkg.info.ObjectOf(name) == obj {
+				return true;
 			}
 		}
 	}
-	return false
+	return false;
 }
 
-// for the time being this just checks a function to see if an opened file is closed
-// http.MaxBytesReader should also be checked for a close
-func closeCheck(f *File, node ast.Node) {
-	var formatString string = "Audit for Close() method called on opened file, %s"
-	// loop through block
-	// look for file open
-	// look for file close
-	// if no file close, report
-	// consider checking if the opened file is returned
-	// consider checking if an open file is an input and no file is returned
-	// it turns out you can open a file and not use it. What then?
-	// ugh I really hate this
-	// is walking the statements a better option?
-	if fun, ok := node.(*ast.FuncDecl); ok {
-		for i, stmts := range fun.Body.List {
-			switch stmt := stmts.(type) {
-			case *ast.AssignStmt:
-				rhs := stmt.Rhs;
-				for _, x := range rhs {
-					if(opensFile(f, x)) {
-						if(!closesFile(f, fun.Body.List[i:])) {
-							f.Reportf(stmt.Pos(), formatString,f.ASTString(x))
-						}
-					}
+// fileUses looks through fun for file being closed or escaping,
+// by being returned, stored, sent or passed to a call that may close it
+func fileUses(f *File, fun ast.Node, file types.Object) (closed, escapes bool) {
+	named := namedResult(f, fun, file);
+	ast.Inspect(fun, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && refersTo(f, sel.X, file) && sel.Sel.Name == "Close" {
+				closed = true;
+			}
+			for _, arg := range n.Args {
+				if refersTo(f, arg, file) && takesFile(f, n) {
+					escapes = true;
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 && named {
+				escapes = true;
+			}
+			for _, result := range n.Results {
+				if refersTo(f, result, file) {
+					escapes = true;
+				}
+			}
+		case *ast.AssignStmt:
+			for _, rhs := range n.Rhs {
+				if refersTo(f, rhs, file) {
+					escapes = true;
 				}
-			case *ast.ExprStmt:
-				if(opensFile(f, stmt.X)) {
-					if(!closesFile(f, fun.Body.List[i:])) {
-						f.Reportf(stmt.Pos(), formatString, f.ASTString(stmt.X))
-					}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value;
 				}
-			case *ast.IfStmt:
-				if s, ok := stmt.Init.(*ast.AssignStmt); ok {
-					rhs := s.Rhs;
-					for _, x := range rhs {
-						if(opensFile(f, x )) {
-							if(!closesFile(f, fun.Body.List[i:])) {
-								f.Reportf(stmt.Pos(), formatString, f.ASTString(x))
-							}
-						}
-					}
+				if refersTo(f, elt, file) {
+					escapes = true;
 				}
-			default:
-				// do nothing for time being
 			}
+		case *ast.SendStmt:
+			if refersTo(f, n.Value, file) {
+				escapes = true;
+			}
+		}
+		return !closed && !escapes;
+	});
+	return closed, escapes;
+}
+
+func closeCheck(f *File, node ast.Node) {
+	var call ast.Expr;
+	var lhs ast.Expr;
+	switch stmt := node.(type) {
+	case *ast.ExprStmt:
+		call = stmt.X;
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) != 1 || len(stmt.Lhs) == 0 {
+			return;
 		}
+		call = stmt.Rhs[0];
+		lhs = stmt.Lhs[0];
+	default:
+		return;
+	}
+	if !opensFile(f, call) {
+		return;
+	}
+	id, ok := lhs.(*ast.Ident);
+	if lhs == nil || ok && id.Name == "_" {
+		f.Reportf(call.Pos(), "file discarded without being closed, leaking a file descriptor: %s", f.ASTString(call));
+		return;
+	}
+	fun := f.enclosingFunc();
+	if !ok || fun == nil {
+		return;
+	}
+	file := f.pkg.info.ObjectOf(id);
+	v, ok := file.(*types.Var);
+	if !ok || f.pkg.typePkg == nil || v.Parent() == f.pkg.typePkg.Scope() {
+		// package level files are closed elsewhere if at all
+		return;
+	}
+	if closed, escapes := fileUses(f, fun, file); closed || escapes {
+		return;
 	}
+	f.Reportf(call.Pos(), "%s is never closed, add defer %s.Close() after checking the error: %s", id.Name, id.Name, f.ASTString(call));
 	return;
 }
@@ -0,0 +1,110 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+func init() {
+	register("contentType",
+		"this tests for HTTP handlers writing a response before setting Content-Type",
+		severityLow,
+		categorySecurity,
+		contentTypeCheck,
+		funcDecl)
+}
+
+// setsContentType checks for w.Header().Set("Content-Type", ...) or Add
+func setsContentType(f *File, call *ast.CallExpr, w types.Object) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok || (sel.Sel.Name != "Set" && sel.Sel.Name != "Add") || len(call.Args) != 2 {
+		return false;
+	}
+	header, ok := sel.X.(*ast.CallExpr);
+	if !ok {
+		return false;
+	}
+	hsel, ok := header.Fun.(*ast.SelectorExpr);
+	if !ok || hsel.Sel.Name != "Header" || !refersTo(f, hsel.X, w) {
+		return false;
+	}
+	key, ok := constString(f, call.Args[0]);
+	return ok && strings.EqualFold(key, "Content-Type");
+}
+
+// writesBody checks for calls that write the response body to w,
+// the body is what a browser sniffs without a Content-Type
+// w.WriteHeader alone sends no body and helpers like http.Error
+// set their own Content-Type, so neither is counted
+func writesBody(f *File, call *ast.CallExpr, w types.Object) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return false;
+	}
+	if refersTo(f, sel.X, w) {
+		return sel.Sel.Name == "Write";
+	}
+	// json.NewEncoder(w).Encode(v)
+	if enc, ok := sel.X.(*ast.CallExpr); ok && sel.Sel.Name == "Encode" && len(enc.Args) == 1 && refersTo(f, enc.Args[0], w) {
+		return true;
+	}
+	if len(call.Args) == 0 || !refersTo(f, call.Args[0], w) {
+		return false;
+	}
+	switch path, name := f.getPkgFunc(call); path {
+	case "fmt":
+		return strings.HasPrefix(name, "Fprint");
+	case "io":
+		return name == "WriteString" || name == "Copy";
+	}
+	// tmpl.Execute(w, data)
+	return sel.Sel.Name == "Execute" || sel.Sel.Name == "ExecuteTemplate";
+}
+
+func contentTypeCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	w, _ := handlerParams(f, fun.Type);
+	if w == nil {
+		return;
+	}
+	set := false;
+	written := token.NoPos;
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		if written != token.NoPos || set {
+			return false;
+		}
+		// w.Header()["Content-Type"] = ...
+		if assign, ok := node.(*ast.AssignStmt); ok {
+			for _, lhs := range assign.Lhs {
+				if index, ok := lhs.(*ast.IndexExpr); ok {
+					if key, ok := constString(f, index.Index); ok && strings.EqualFold(key, "Content-Type") {
+						set = true;
+					}
+				}
+			}
+		}
+		call, ok := node.(*ast.CallExpr);
+		if !ok {
+			return true;
+		}
+		if setsContentType(f, call, w) {
+			set = true;
+		} else if writesBody(f, call, w) {
+			written = call.Pos();
+		}
+		return true;
+	})
+	if written == token.NoPos {
+		return;
+	}
+	f.Reportf(written, "handler %s writes the response before setting Content-Type, browsers may sniff it as HTML", fun.Name.Name);
+	return;
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("copyLock",
+		"this tests for values containing a sync.Mutex or other lock copied by value",
+		severityMedium,
+		categoryCorrectness,
+		copyLockCheck,
+		assignStmt, callExpr, funcDecl, returnStmt)
+}
+
+// lockPath returns the path to a lock inside t, such as "sync.Mutex"
+// or "server.mu sync.Mutex", or "" if t holds no lock
+// a lock is a sync type or anything whose pointer has Lock and Unlock
+// methods, the same noCopy convention go vet uses
+func lockPath(t types.Type, seen map[types.Type]bool) string {
+	if t == nil || seen[t] {
+		return "";
+	}
+	seen[t] = true;
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj();
+		if obj.Pkg() != nil && obj.Pkg().Path() == "sync" {
+			switch obj.Name() {
+			case "Mutex", "RWMutex", "WaitGroup", "Once", "Cond", "Map", "Pool":
+				return "sync." + obj.Name();
+			}
+		}
+		mset := types.NewMethodSet(types.NewPointer(t));
+		if mset.Lookup(obj.Pkg(), "Lock") != nil && mset.Lookup(obj.Pkg(), "Unlock") != nil {
+			if _, ok := t.Underlying().(*types.Interface); !ok {
+				return obj.Name();
+			}
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i);
+			if path := lockPath(field.Type(), seen); path != "" {
+				return field.Name() + " " + path;
+			}
+		}
+	case *types.Array:
+		return lockPath(u.Elem(), seen);
+	}
+	return "";
+}
+
+// copiesLock returns the lock held by x when evaluating x copies an existing value,
+// a variable, field, element or dereference, rather than making a new one
+func copiesLock(f *File, x ast.Expr) string {
+	x = ast.Unparen(x);
+	switch x := x.(type) {
+	case *ast.Ident:
+		if _, ok := f.pkg.info.ObjectOf(x).(*types.Var); !ok {
+			return "";
+		}
+	case *ast.SelectorExpr:
+		if selection, ok := f.pkg.info.Selections[x]; !ok || selection.Kind() != types.FieldVal {
+			return "";
+		}
+	case *ast.IndexExpr, *ast.StarExpr:
+	default:
+		return "";
+	}
+	return lockPath(f.pkg.info.TypeOf(x), make(map[types.Type]bool));
+}
+
+// checkLockParams reports receivers and parameters that take a lock by value
+func checkLockParams(f *File, fields *ast.FieldList, kind string) {
+	if fields == nil {
+		return;
+	}
+	for _, field := range fields.List {
+		if path := lockPath(f.pkg.info.TypeOf(field.Type), make(map[types.Type]bool)); path != "" {
+			f.Reportf(field.Pos(), "%s passes lock by value: %s contains %s, use a pointer", kind, f.ASTString(field.Type), path);
+		}
+	}
+}
+
+func copyLockCheck(f *File, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		checkLockParams(f, n.Recv, "receiver");
+		checkLockParams(f, n.Type.Params, "parameter");
+	case *ast.AssignStmt:
+		for _, rhs := range n.Rhs {
+			if path := copiesLock(f, rhs); path != "" {
+				f.Reportf(rhs.Pos(), "assignment copies lock value: %s contains %s", f.ASTString(rhs), path);
+			}
+		}
+	case *ast.CallExpr:
+		// conversions like T(x) are left to the assignment they are part of
+		if f.pkg.info.Types[n.Fun].IsType() {
+			return;
+		}
+		for _, arg := range n.Args {
+			if path := copiesLock(f, arg); path != "" {
+				f.Reportf(arg.Pos(), "call copies lock value: %s contains %s", f.ASTString(arg), path);
+			}
+		}
+	case *ast.ReturnStmt:
+		for _, result := range n.Results {
+			if path := copiesLock(f, result); path != "" {
+				f.Reportf(result.Pos(), "return copies lock value: %s contains %s", f.ASTString(result), path);
+			}
+		}
+	}
+	return;
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+)
+
+var ctorFields = flag.Bool("ctor-fields", false, "report writes to struct fields of types from packages with a constructor for them")
+
+func init() {
+	registerOptIn("ctorField",
+		"this tests for writes to fields of another package's type when that package has a New constructor for it",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *ctorFields;
+		},
+		ctorFieldCheck,
+		assignStmt)
+}
+
+// constructor returns the name of the New function for a named type
+// if the type's package declares one
+func constructor(named *types.Named) string {
+	obj := named.Obj();
+	if obj.Pkg() == nil {
+		return "";
+	}
+	name := "New" + obj.Name();
+	if _, ok := obj.Pkg().Scope().Lookup(name).(*types.Func); ok {
+		return obj.Pkg().Name() + "." + name;
+	}
+	return "";
+}
+
+func ctorFieldCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.AssignStmt);
+	if !ok {
+		return;
+	}
+	for _, lhs := range stmt.Lhs {
+		sel, ok := lhs.(*ast.SelectorExpr);
+		if !ok {
+			continue;
+		}
+		selection, ok := f.pkg.info.Selections[sel];
+		if !ok || selection.Kind() != types.FieldVal {
+			continue;
+		}
+		recv := selection.Recv();
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem();
+		}
+		named, ok := recv.(*types.Named);
+		if !ok || named.Obj().Pkg() == f.pkg.typePkg {
+			continue;
+		}
+		if ctor := constructor(named); ctor != "" {
+			f.Reportf(stmt.Pos(), "field %s set directly, %s may enforce invariants", f.ASTString(sel), ctor);
+		}
+	}
+	return;
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// functions whose context aware version isn't just the name plus Context
+var contextVariants = map[string]string{
+	"net/http.NewRequest":	"NewRequestWithContext",
+}
+
+func init() {
+	register("ctxPropagate",
+		"this tests for calls that have a Context variant made where a context.Context is available",
+		severityLow,
+		categoryCorrectness,
+		ctxPropagateCheck,
+		callExpr)
+}
+
+// isContext checks if a type is context.Context
+func isContext(t types.Type) bool {
+	return t != nil && t.String() == "context.Context";
+}
+
+// hasContextParam checks the functions enclosing the node being visited
+// for a context.Context parameter
+func hasContextParam(f *File) bool {
+	for _, node := range f.stack {
+		var ftype *ast.FuncType;
+		switch fun := node.(type) {
+		case *ast.FuncDecl:
+			ftype = fun.Type;
+		case *ast.FuncLit:
+			ftype = fun.Type;
+		default:
+			continue;
+		}
+		for _, field := range ftype.Params.List {
+			if isContext(f.pkg.info.TypeOf(field.Type)) {
+				return true;
+			}
+		}
+	}
+	return false;
+}
+
+// contextVariant returns the name of the context aware version
+// of a called function or method, i.e. QueryContext for db.Query
+func contextVariant(f *File, call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return "";
+	}
+	name := sel.Sel.Name + "Context";
+	if selection, ok := f.pkg.info.Selections[sel]; ok {
+		if selection.Kind() != types.MethodVal {
+			return "";
+		}
+		obj, _, _ := types.LookupFieldOrMethod(selection.Recv(), true, selection.Obj().Pkg(), name);
+		if _, ok := obj.(*types.Func); ok {
+			return name;
+		}
+		return "";
+	}
+	path := f.importPath(sel.X);
+	if path == "" {
+		return "";
+	}
+	if variant, ok := contextVariants[path+"."+sel.Sel.Name]; ok {
+		return variant;
+	}
+	if pkgName, ok := f.pkg.info.Uses[sel.X.(*ast.Ident)].(*types.PkgName); ok {
+		if _, ok := pkgName.Imported().Scope().Lookup(name).(*types.Func); ok {
+			return name;
+		}
+	}
+	return "";
+}
+
+func ctxPropagateCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	// already passing a context
+	for _, arg := range call.Args {
+		if isContext(f.pkg.info.TypeOf(arg)) {
+			return;
+		}
+	}
+	variant := contextVariant(f, call);
+	if variant == "" || !hasContextParam(f) {
+		return;
+	}
+	f.Reportf(call.Pos(), "context available but not passed on, use %s instead of %s", variant, f.ASTString(call.Fun));
+	return;
+}
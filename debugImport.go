@@ -0,0 +1,43 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+var debugImportAllow = flag.String("debug-import-allow", "", "comma separated list of packages allowed to import net/http/pprof and expvar for side effects")
+
+func init() {
+	register("debugImport",
+		"this tests for blank imports that register debug endpoints on http.DefaultServeMux",
+		severityMedium,
+		categorySecurity,
+		debugImportCheck,
+		genDecl)
+}
+
+func debugImportCheck(f *File, node ast.Node) {
+	decl, ok := node.(*ast.GenDecl);
+	if !ok || decl.Tok != token.IMPORT {
+		return;
+	}
+	if inList(*debugImportAllow, f.file.Name.Name) {
+		return;
+	}
+	for _, spec := range decl.Specs {
+		imp, ok := spec.(*ast.ImportSpec);
+		if !ok || imp.Name == nil || imp.Name.Name != "_" {
+			continue;
+		}
+		path := strings.Trim(imp.Path.Value, "\"");
+		if path == "net/http/pprof" || path == "expvar" {
+			f.Reportf(imp.Pos(), "import of %s exposes debug endpoints on http.DefaultServeMux", path);
+		}
+	}
+	return;
+}
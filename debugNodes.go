@@ -0,0 +1,45 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+var debugNodes = flag.Bool("debug-nodes", false, "instead of running checkers print how many of each node type checkers can register for are in each file")
+
+// countNode counts a node of the type key for -debug-nodes
+func (f *File) countNode(key ast.Node) {
+	if f.nodeCounts == nil {
+		f.nodeCounts = make(map[ast.Node]int);
+	}
+	f.nodeCounts[key]++;
+}
+
+// writeNodeCounts prints a file's node types, most common first,
+// with how many registered checkers each type runs
+func writeNodeCounts(w io.Writer, name string, counts map[ast.Node]int) error {
+	var keys []ast.Node;
+	for key := range counts {
+		keys = append(keys, key);
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]];
+		}
+		return fmt.Sprintf("%T", keys[i]) < fmt.Sprintf("%T", keys[j]);
+	})
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0);
+	fmt.Fprintf(tw, "%s\n", name);
+	fmt.Fprintf(tw, "\tnode\tcount\tcheckers\n");
+	for _, key := range keys {
+		fmt.Fprintf(tw, "\t%T\t%d\t%d\n", key, counts[key], len(checkers[key]));
+	}
+	return tw.Flush();
+}
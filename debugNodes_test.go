@@ -0,0 +1,78 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const debugNodesSrc = `package p
+
+func a(x int) int {
+	x = x + 1
+	y := x * 2
+	return y
+}
+
+func b() {
+	go a(1)
+}
+`
+
+// TestDebugNodes checks -debug-nodes counts each checked node type in a file
+// and prints them most common first
+func TestDebugNodes(t *testing.T) {
+	fset := token.NewFileSet();
+	parsed, err := parser.ParseFile(fset, "nodes.go", debugNodesSrc, parser.ParseComments);
+	if err != nil {
+		t.Fatal(err);
+	}
+	pkg := new(Package);
+	if err := pkg.check(fset, []*ast.File{parsed}); err != nil {
+		t.Fatal(err);
+	}
+	setFlag(t, "debug-nodes", "true");
+	file := &File{pkg: pkg, fset: fset, name: "nodes.go", file: parsed};
+	ast.Walk(file, parsed);
+	want := map[ast.Node]int{
+		fileNode:	1,
+		funcDecl:	2,
+		assignStmt:	2,
+		binaryExpr:	2,
+		basicLit:	3,
+		returnStmt:	1,
+		goStmt:		1,
+		callExpr:	1,
+	}
+	for key, n := range want {
+		if file.nodeCounts[key] != n {
+			t.Errorf("%T counted %d times, want %d", key, file.nodeCounts[key], n);
+		}
+	}
+	if len(file.nodeCounts) != len(want) {
+		t.Errorf("got counts %v, want %v", file.nodeCounts, want);
+	}
+	var b bytes.Buffer;
+	if err := writeNodeCounts(&b, "nodes.go", file.nodeCounts); err != nil {
+		t.Fatal(err);
+	}
+	lines := strings.Split(b.String(), "\n");
+	if len(lines) < 3 || lines[0] != "nodes.go" || len(strings.Fields(lines[1])) != 3 {
+		t.Fatalf("got header:\n%s", b.String());
+	}
+	// basic literals are the most common, ties are in type name order
+	first := strings.Fields(lines[2]);
+	if len(first) != 3 || first[0] != "*ast.BasicLit" || first[1] != "3" || first[2] != fmt.Sprint(len(checkers[basicLit])) {
+		t.Errorf("got first row %q, want *ast.BasicLit 3 %d", lines[2], len(checkers[basicLit]));
+	}
+	if second := strings.Fields(lines[3]); len(second) == 0 || second[0] != "*ast.AssignStmt" {
+		t.Errorf("got second row %q, want *ast.AssignStmt", lines[3]);
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("defaultMux",
+		"this tests for handlers registered on or served from http.DefaultServeMux",
+		severityLow,
+		categorySecurity,
+		defaultMuxCheck,
+		callExpr)
+}
+
+// isNil checks if an expression is the nil identifier
+func isNil(x ast.Expr) bool {
+	id, ok := x.(*ast.Ident);
+	return ok && id.Name == "nil";
+}
+
+func defaultMuxCheck(f *File, node ast.Node) {
+	formatString := "audit use of http.DefaultServeMux, use an explicit http.ServeMux: %s";
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	if path != "net/http" {
+		return;
+	}
+	switch name {
+	case "Handle", "HandleFunc":
+		f.Reportf(call.Pos(), formatString, f.ASTString(call));
+	case "ListenAndServe", "ListenAndServeTLS":
+		// the handler is always the last argument
+		if n := len(call.Args); n > 0 && isNil(call.Args[n-1]) {
+			f.Reportf(call.Pos(), formatString, f.ASTString(call));
+		}
+	}
+	return;
+}
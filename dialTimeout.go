@@ -0,0 +1,35 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("dialTimeout",
+		"this tests for network connections dialed without a timeout",
+		severityLow,
+		categoryCorrectness,
+		dialTimeoutCheck,
+		callExpr)
+}
+
+func dialTimeoutCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	var fix string;
+	switch path, name := f.getPkgFunc(call); path + "." + name {
+	case "net.Dial", "net.DialTCP", "net.DialUnix", "net.DialIP":
+		fix = "net.DialTimeout or a net.Dialer with a Timeout or DialContext";
+	case "crypto/tls.Dial":
+		fix = "tls.DialWithDialer with a net.Dialer Timeout or a tls.Dialer with DialContext";
+	default:
+		return;
+	}
+	f.Reportf(call.Pos(), "dial without a timeout can hang forever, use %s: %s", fix, f.ASTString(call));
+	return;
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("doubleClose",
+		"this tests for channels closed twice in the same block",
+		severityMedium,
+		categoryCorrectness,
+		doubleCloseCheck,
+		funcDecl)
+}
+
+// closedChan returns the channel closed by a close(ch) statement
+func closedChan(f *File, stmt ast.Stmt) types.Object {
+	expr, ok := stmt.(*ast.ExprStmt);
+	if !ok {
+		return nil;
+	}
+	call, ok := expr.X.(*ast.CallExpr);
+	if !ok || len(call.Args) != 1 || !isBuiltin(f, call, "close") {
+		return nil;
+	}
+	if id, ok := call.Args[0].(*ast.Ident); ok {
+		return f.pkg.info.ObjectOf(id);
+	}
+	return nil;
+}
+
+// checkCloses reports a channel closed a second time in a list of statements
+func checkCloses(f *File, stmts []ast.Stmt) {
+	closed := make(map[types.Object]token.Pos);
+	for _, stmt := range stmts {
+		obj := closedChan(f, stmt);
+		if obj == nil {
+			continue;
+		}
+		if first, ok := closed[obj]; ok {
+			f.Reportf(stmt.Pos(), "channel %s closed twice, first closed at %s, this panics", obj.Name(), f.loc(first));
+			continue;
+		}
+		closed[obj] = stmt.Pos();
+	}
+}
+
+func doubleCloseCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		switch block := node.(type) {
+		case *ast.BlockStmt:
+			checkCloses(f, block.List);
+		case *ast.CaseClause:
+			checkCloses(f, block.Body);
+		case *ast.CommClause:
+			checkCloses(f, block.Body);
+		}
+		return true;
+	})
+	return;
+}
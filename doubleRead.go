@@ -0,0 +1,66 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("doubleRead",
+		"this tests for the same file read or opened more than once in a function",
+		severityLow,
+		categoryPerformance,
+		doubleReadCheck,
+		funcDecl)
+}
+
+// readsFile returns the path argument of calls that read or open a file
+func readsFile(f *File, call *ast.CallExpr) ast.Expr {
+	path, name := f.getPkgFunc(call);
+	if len(call.Args) == 0 {
+		return nil;
+	}
+	switch path {
+	case "os":
+		if name == "ReadFile" || name == "Open" || name == "OpenFile" {
+			return call.Args[0];
+		}
+	case "io/ioutil":
+		if name == "ReadFile" {
+			return call.Args[0];
+		}
+	}
+	return nil;
+}
+
+func doubleReadCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	read := make(map[string]token.Pos);
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr);
+		if !ok {
+			return true;
+		}
+		arg := readsFile(f, call);
+		if arg == nil {
+			return true;
+		}
+		name, ok := constString(f, arg);
+		if !ok {
+			return true;
+		}
+		if first, ok := read[name]; ok {
+			f.Reportf(call.Pos(), "%q already read at %s, reuse the data: %s", name, f.loc(first), f.ASTString(call));
+			return true;
+		}
+		read[name] = call.Pos();
+		return true;
+	})
+	return;
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("dupCase",
+		"this tests for switch statements with the same case value more than once",
+		severityMedium,
+		categoryCorrectness,
+		dupCaseCheck,
+		switchStmt)
+}
+
+// caseKey identifies a case expression, by constant value when it has one
+// and otherwise by its source, which is how tagless switch conditions repeat
+func caseKey(f *File, x ast.Expr, tagless bool) (string, bool) {
+	if tv, ok := f.pkg.info.Types[x]; ok && tv.Value != nil {
+		return tv.Type.String() + " " + tv.Value.ExactString(), true;
+	}
+	if tagless {
+		return f.ASTString(x), true;
+	}
+	return "", false;
+}
+
+func dupCaseCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.SwitchStmt);
+	if !ok {
+		return;
+	}
+	seen := make(map[string]token.Pos);
+	for _, clause := range stmt.Body.List {
+		cc, ok := clause.(*ast.CaseClause);
+		if !ok {
+			continue;
+		}
+		for _, x := range cc.List {
+			key, ok := caseKey(f, x, stmt.Tag == nil);
+			if !ok {
+				continue;
+			}
+			if first, ok := seen[key]; ok {
+				f.Reportf(x.Pos(), "duplicate case %s, first at %s, the later case never runs", f.ASTString(x), f.loc(first));
+				continue;
+			}
+			seen[key] = x.Pos();
+		}
+	}
+	return;
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("dynFormat",
+		"this tests for printf style calls with a format string that is not a constant",
+		severityMedium,
+		categorySecurity,
+		dynFormatCheck,
+		callExpr)
+}
+
+// printfFormat returns the index of the format argument of
+// printf style functions or -1
+func printfFormat(path, name string) int {
+	switch path {
+	case "fmt":
+		switch name {
+		case "Printf", "Sprintf", "Errorf":
+			return 0;
+		case "Fprintf":
+			return 1;
+		}
+	case "log":
+		switch name {
+		case "Printf", "Fatalf", "Panicf":
+			return 0;
+		}
+	}
+	return -1;
+}
+
+// isConst checks if an expression is a constant such as a string literal
+func isConst(f *File, x ast.Expr) bool {
+	if tv, ok := f.pkg.info.Types[x]; ok {
+		return tv.Value != nil;
+	}
+	lit, ok := x.(*ast.BasicLit);
+	return ok && lit.Kind == token.STRING;
+}
+
+func dynFormatCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	index := printfFormat(f.getPkgFunc(call));
+	if index < 0 || index >= len(call.Args) || call.Ellipsis.IsValid() {
+		return;
+	}
+	format := call.Args[index];
+	if !isConst(f, format) {
+		f.Reportf(call.Pos(), "format string %s is not a constant, use a literal format such as \"%%s\": %s", f.ASTString(format), f.ASTString(call));
+	}
+	return;
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+func init() {
+	register("emptyCase",
+		"this tests for type switch cases with empty bodies that likely should handle the type",
+		severityLow,
+		categoryCorrectness,
+		emptyCaseCheck,
+		typeSwitchStmt)
+}
+
+// caseComment returns the text of comments between from and to
+// an empty case with a comment is left empty on purpose
+func caseComment(f *File, from, to token.Pos) string {
+	var text string;
+	for _, group := range f.file.Comments {
+		if group.Pos() > from && group.End() < to {
+			text += group.Text();
+		}
+	}
+	return text;
+}
+
+func emptyCaseCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.TypeSwitchStmt);
+	if !ok {
+		return;
+	}
+	clauses := stmt.Body.List;
+	for i, s := range clauses {
+		clause, ok := s.(*ast.CaseClause);
+		// default: is often left empty to ignore other types
+		if !ok || clause.List == nil || len(clause.Body) != 0 {
+			continue;
+		}
+		end := stmt.Body.Rbrace;
+		if i+1 < len(clauses) {
+			end = clauses[i+1].Pos();
+		}
+		comment := caseComment(f, clause.Colon, end);
+		if comment != "" && !strings.Contains(strings.ToUpper(comment), "TODO") {
+			continue;
+		}
+		var types []string;
+		for _, t := range clause.List {
+			types = append(types, f.ASTString(t));
+		}
+		f.Reportf(clause.Pos(), "empty type switch case %s does nothing and does not fall through, handle it or say why it is empty", strings.Join(types, ", "));
+	}
+	return;
+}
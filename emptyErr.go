@@ -0,0 +1,64 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+func init() {
+	register("emptyErr",
+		"this tests for if err != nil blocks that do nothing with the error",
+		severityMedium,
+		categoryCorrectness,
+		emptyErrCheck,
+		ifStmt)
+}
+
+// isErrNotNil checks if an expression is of the form err != nil
+func isErrNotNil(f *File, x ast.Expr) bool {
+	cond, ok := x.(*ast.BinaryExpr);
+	if !ok || cond.Op != token.NEQ {
+		return false;
+	}
+	lhs, rhs := cond.X, cond.Y;
+	if id, ok := lhs.(*ast.Ident); ok && id.Name == "nil" {
+		lhs, rhs = rhs, lhs;
+	}
+	if id, ok := rhs.(*ast.Ident); !ok || id.Name != "nil" {
+		return false;
+	}
+	return isError(f.pkg.info.TypeOf(lhs));
+}
+
+// onlyTODO checks if the comments inside a block are just a TODO note
+func onlyTODO(f *File, block *ast.BlockStmt) bool {
+	for _, group := range f.file.Comments {
+		if group.Pos() > block.Lbrace && group.End() < block.Rbrace {
+			if strings.Contains(strings.ToUpper(group.Text()), "TODO") {
+				return true;
+			}
+		}
+	}
+	return false;
+}
+
+func emptyErrCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.IfStmt);
+	if !ok {
+		return;
+	}
+	if len(stmt.Body.List) != 0 || !isErrNotNil(f, stmt.Cond) {
+		return;
+	}
+	cond := f.ASTString(stmt.Cond);
+	if onlyTODO(f, stmt.Body) {
+		f.Reportf(stmt.Pos(), "error handling left as a TODO, if %s {}", cond);
+		return;
+	}
+	f.Reportf(stmt.Pos(), "error ignored in empty block, if %s {}", cond);
+	return;
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("errOverwrite",
+		"this tests for errors overwritten by another call before being checked",
+		severityMedium,
+		categoryCorrectness,
+		errOverwriteCheck,
+		funcDecl, funcLit)
+}
+
+// callErrors returns the error variables an assignment sets from a call
+func callErrors(f *File, assign *ast.AssignStmt) []types.Object {
+	if len(assign.Rhs) != 1 {
+		return nil;
+	}
+	if _, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); !ok {
+		return nil;
+	}
+	var errs []types.Object;
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident);
+		if !ok {
+			continue;
+		}
+		if obj := f.pkg.info.ObjectOf(id); obj != nil && isError(obj.Type()) {
+			errs = append(errs, obj);
+		}
+	}
+	return errs;
+}
+
+// usesErr checks if stmt reads obj anywhere, apart from the left hand
+// side of stmt itself when it is an assignment
+func usesErr(f *File, stmt ast.Stmt, obj types.Object) bool {
+	used := false;
+	check := func(node ast.Node) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && f.pkg.info.ObjectOf(id) == obj {
+				used = true;
+			}
+			return !used;
+		});
+	}
+	if assign, ok := stmt.(*ast.AssignStmt); ok {
+		for _, rhs := range assign.Rhs {
+			check(rhs);
+		}
+		return used;
+	}
+	check(stmt);
+	return used;
+}
+
+// checkOverwrites goes through a list of statements in order and reports
+// errors set by a call and set again by another call without being read
+func checkOverwrites(f *File, stmts []ast.Stmt) {
+	pending := make(map[types.Object]token.Pos);
+	for _, stmt := range stmts {
+		for obj := range pending {
+			if usesErr(f, stmt, obj) {
+				delete(pending, obj);
+			}
+		}
+		assign, ok := stmt.(*ast.AssignStmt);
+		if !ok {
+			continue;
+		}
+		errs := callErrors(f, assign);
+		for _, obj := range errs {
+			if pos, ok := pending[obj]; ok {
+				f.Reportf(assign.Pos(), "%s set at %s is overwritten before being checked: %s", obj.Name(), f.loc(pos), f.ASTString(assign.Rhs[0]));
+			}
+			pending[obj] = assign.Pos();
+		}
+		if len(errs) != 0 {
+			continue;
+		}
+		// err = nil and other assignments not from a call are deliberate
+		for _, lhs := range assign.Lhs {
+			if id, ok := lhs.(*ast.Ident); ok {
+				delete(pending, f.pkg.info.ObjectOf(id));
+			}
+		}
+	}
+}
+
+func errOverwriteCheck(f *File, node ast.Node) {
+	var body *ast.BlockStmt;
+	switch fun := node.(type) {
+	case *ast.FuncDecl:
+		body = fun.Body;
+	case *ast.FuncLit:
+		body = fun.Body;
+	}
+	if body == nil {
+		return;
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// function literals are checked on their own
+			return false;
+		case *ast.BlockStmt:
+			checkOverwrites(f, n.List);
+		case *ast.CaseClause:
+			checkOverwrites(f, n.Body);
+		case *ast.CommClause:
+			checkOverwrites(f, n.Body);
+		}
+		return true;
+	});
+	return;
+}
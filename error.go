@@ -10,11 +10,18 @@ import (
 func init() {
 	register("error",
 		"this tests to see if any errors were ignored",
+		severityMedium,
+		categoryCorrectness,
 		errorCheck,
 		assignStmt,
 		exprStmt)
 }
 
+// isError reports whether a type is the built in error interface
+func isError(t types.Type) bool {
+	return t != nil && t.String() == "error";
+}
+
 func returnsError(f *File, call *ast.CallExpr) int {
 	if typeValue := f.pkg.info.TypeOf(call); typeValue != nil {
 		switch t := typeValue.(type) {
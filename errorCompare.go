@@ -0,0 +1,59 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("errorCompare",
+		"this tests for errors compared by their message string",
+		severityMedium,
+		categoryCorrectness,
+		errorCompareCheck,
+		binaryExpr)
+}
+
+// implementsError checks if t is error or a concrete error type
+func implementsError(t types.Type) bool {
+	if t == nil {
+		return false;
+	}
+	iface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface);
+	return types.Implements(t, iface);
+}
+
+// errorMessage checks if x is err.Error() on an error value
+func errorMessage(f *File, x ast.Expr) bool {
+	call, ok := ast.Unparen(x).(*ast.CallExpr);
+	if !ok || len(call.Args) != 0 {
+		return false;
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	return ok && sel.Sel.Name == "Error" && implementsError(f.pkg.info.TypeOf(sel.X));
+}
+
+func errorCompareCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return;
+	}
+	var msg ast.Expr;
+	switch {
+	case errorMessage(f, expr.X):
+		msg = expr.Y;
+	case errorMessage(f, expr.Y):
+		msg = expr.X;
+	default:
+		return;
+	}
+	if _, ok := constString(f, msg); !ok {
+		return;
+	}
+	f.Reportf(expr.Pos(), "error compared to the string %s, use a sentinel error and errors.Is: %s", f.ASTString(msg), f.ASTString(expr));
+	return;
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func init() {
+	register("errorString",
+		"this tests for error strings that are capitalized or end with punctuation, fixable with -fix",
+		severityLow,
+		categoryStyle,
+		errorStringCheck,
+		callExpr)
+}
+
+// capitalized checks if a message starts with a capitalized word
+// words like HTTP, EOF and ReadFile with more capitals or digits are
+// taken to be acronyms or identifiers and are allowed
+func capitalized(msg string) bool {
+	word := strings.FieldsFunc(msg, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r);
+	});
+	if len(word) == 0 {
+		return false;
+	}
+	first, size := utf8.DecodeRuneInString(word[0]);
+	if !unicode.IsUpper(first) {
+		return false;
+	}
+	for _, r := range word[0][size:] {
+		if unicode.IsUpper(r) || unicode.IsDigit(r) {
+			return false;
+		}
+	}
+	// the word must start the message, not follow a quote or other punctuation
+	return strings.HasPrefix(msg, word[0]);
+}
+
+func errorStringCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) == 0 {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	if !(path == "errors" && name == "New") && !(path == "fmt" && name == "Errorf") {
+		return;
+	}
+	msg, ok := constString(f, call.Args[0]);
+	if !ok || msg == "" {
+		return;
+	}
+	// only literals written in place can be fixed, not named constants
+	lit, _ := call.Args[0].(*ast.BasicLit);
+	if capitalized(msg) {
+		var fix []Edit;
+		if lit != nil {
+			first, size := utf8.DecodeRuneInString(lit.Value[1:]);
+			start := lit.Pos() + 1;
+			fix = []Edit{f.edit(start, start + token.Pos(size), string(unicode.ToLower(first)))};
+		}
+		f.ReportFix(call.Args[0].Pos(), fix, "error strings should not be capitalized: %s", f.ASTString(call.Args[0]));
+	}
+	if last, _ := utf8.DecodeLastRuneInString(msg); strings.ContainsRune(".!?:\n", last) {
+		var fix []Edit;
+		// a newline is written as an escape, only single punctuation bytes are dropped
+		if lit != nil && last != '\n' && len(lit.Value) > 2 && rune(lit.Value[len(lit.Value)-2]) == last {
+			end := lit.End() - 1;
+			fix = []Edit{f.edit(end - 1, end, "")};
+		}
+		f.ReportFix(call.Args[0].Pos(), fix, "error strings should not end with punctuation or a newline: %s", f.ASTString(call.Args[0]));
+	}
+	return;
+}
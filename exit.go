@@ -0,0 +1,83 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"strings"
+)
+
+var exitAllow = flag.String("exit-allow", "", "comma separated list of packages allowed to call os.Exit and log.Fatal")
+
+func init() {
+	register("exit",
+		"this tests for os.Exit and log.Fatal outside of main and init and in functions with defer statements",
+		severityMedium,
+		categoryCorrectness,
+		exitCheck,
+		callExpr)
+}
+
+// exits reports whether a call ends the program without running deferred functions
+func exits(path, name string) bool {
+	switch path {
+	case "os":
+		return name == "Exit";
+	case "log":
+		return name == "Fatal" || name == "Fatalf" || name == "Fatalln";
+	}
+	return false;
+}
+
+// hasDefer checks a function body for defer statements
+// defer statements in nested function literals belong to those functions
+func hasDefer(body *ast.BlockStmt) bool {
+	found := false;
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.DeferStmt:
+			found = true;
+		case *ast.FuncLit:
+			return false;
+		}
+		return !found;
+	})
+	return found;
+}
+
+func exitCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	if !exits(path, name) {
+		return;
+	}
+	if strings.HasSuffix(f.name, "_test.go") || inList(*exitAllow, f.file.Name.Name) {
+		return;
+	}
+
+	// the defers in the enclosing function will not run
+	var body *ast.BlockStmt;
+	switch fun := f.enclosingFunc().(type) {
+	case *ast.FuncDecl:
+		body = fun.Body;
+	case *ast.FuncLit:
+		body = fun.Body;
+	}
+	if name == "Exit" && body != nil && hasDefer(body) {
+		f.Reportf(call.Pos(), "deferred calls will not run after %s", f.ASTString(call));
+		return;
+	}
+
+	if fun := f.enclosingFuncDecl(); fun != nil && fun.Recv == nil {
+		if fun.Name.Name == "main" || fun.Name.Name == "init" {
+			return;
+		}
+	}
+	f.Reportf(call.Pos(), "audit %s outside of main, deferred calls are skipped", f.ASTString(call));
+	return;
+}
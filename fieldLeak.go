@@ -0,0 +1,50 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+)
+
+var fieldLeaks = flag.Bool("field-leaks", false, "report exported methods returning their receiver's map or slice fields")
+
+func init() {
+	registerOptIn("fieldLeak",
+		"this tests for exported methods returning a map or slice field of their receiver",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *fieldLeaks;
+		},
+		fieldLeakCheck,
+		returnStmt)
+}
+
+func fieldLeakCheck(f *File, node ast.Node) {
+	ret, ok := node.(*ast.ReturnStmt);
+	if !ok {
+		return;
+	}
+	fun, ok := f.enclosingFunc().(*ast.FuncDecl);
+	if !ok || fun.Recv == nil || len(fun.Recv.List) == 0 || len(fun.Recv.List[0].Names) == 0 || !exportedFunc(f, fun) {
+		return;
+	}
+	recv := f.pkg.info.Defs[fun.Recv.List[0].Names[0]];
+	for _, result := range ret.Results {
+		sel, ok := result.(*ast.SelectorExpr);
+		if !ok || !refersTo(f, sel.X, recv) {
+			continue;
+		}
+		if selection, ok := f.pkg.info.Selections[sel]; !ok || selection.Kind() != types.FieldVal {
+			continue;
+		}
+		switch f.pkg.info.TypeOf(sel).Underlying().(type) {
+		case *types.Map, *types.Slice:
+			f.Reportf(sel.Pos(), "%s returns internal field %s, callers can modify it, return a copy", fun.Name.Name, f.ASTString(sel));
+		}
+	}
+	return;
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+)
+
+var fixFindings = flag.Bool("fix", false, "rewrite files to apply the fixes of fixable checkers")
+
+// Edit replaces the bytes from Offset up to End of a file with Text
+// File is the real path, not the display path findings are reported with
+type Edit struct {
+	File	string
+	Offset	int
+	End	int
+	Text	string
+}
+
+// edit makes an Edit replacing the source between pos and end
+func (f *File) edit(pos, end token.Pos, text string) Edit {
+	start := f.fset.Position(pos);
+	return Edit{
+		File:	start.Filename,
+		Offset:	start.Offset,
+		End:	f.fset.Position(end).Offset,
+		Text:	text,
+	}
+}
+
+// applyFixes rewrites files with the edits of findings that have them
+// and returns the findings left unfixed
+func applyFixes(findings []Finding) []Finding {
+	byFile := make(map[string][]Edit);
+	for _, finding := range findings {
+		for _, e := range finding.Fix {
+			byFile[e.File] = append(byFile[e.File], e);
+		}
+	}
+	var names []string;
+	for name := range byFile {
+		names = append(names, name);
+	}
+	sort.Strings(names);
+	failed := make(map[string]bool);
+	for _, name := range names {
+		edits := byFile[name];
+		if err := fixFile(name, edits); err != nil {
+			warnf("cannot fix %s: %s", displayPath(name), err);
+			failed[name] = true;
+			continue;
+		}
+		if *outputFormat == "text" {
+			fmt.Printf("Fixed %s\n", displayPath(name));
+		}
+	}
+	var unfixed []Finding;
+	for _, finding := range findings {
+		if len(finding.Fix) == 0 || failed[finding.Fix[0].File] {
+			unfixed = append(unfixed, finding);
+		}
+	}
+	return unfixed;
+}
+
+// fixFile applies edits to a file from the end back so earlier offsets stay put,
+// then gofmts the result so the file is left formatted
+func fixFile(name string, edits []Edit) error {
+	info, err := os.Stat(name);
+	if err != nil {
+		return err;
+	}
+	src, err := os.ReadFile(name);
+	if err != nil {
+		return err;
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].Offset != edits[j].Offset {
+			return edits[i].Offset > edits[j].Offset;
+		}
+		return edits[i].End > edits[j].End;
+	});
+	last := len(src) + 1;
+	for i, e := range edits {
+		// several findings can carry the same edit, like an import change
+		if i > 0 && e == edits[i-1] {
+			continue;
+		}
+		if e.Offset < 0 || e.End > len(src) || e.Offset > e.End {
+			return fmt.Errorf("edit at offset %d is outside the file, it may have changed", e.Offset);
+		}
+		if e.End > last {
+			return fmt.Errorf("overlapping edits at offset %d", e.Offset);
+		}
+		src = append(src[:e.Offset], append([]byte(e.Text), src[e.End:]...)...);
+		last = e.Offset;
+	}
+	formatted, err := format.Source(src);
+	if err != nil {
+		return fmt.Errorf("fixed source does not parse: %s", err);
+	}
+	return os.WriteFile(name, formatted, info.Mode());
+}
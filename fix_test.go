@@ -0,0 +1,81 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixFile(t *testing.T) {
+	const src = "package main\n\nfunc a() {}\n\nfunc b() {}\n";
+	a, b := strings.Index(src, "a()"), strings.Index(src, "b()");
+	body := strings.Index(src, "{}") + 1;
+	tests := []struct {
+		name	string
+		edits	[]Edit
+		want	string
+		err	bool
+	}{
+		{"none", nil, src, false},
+		{
+			"in any order",
+			[]Edit{{Offset: a, End: a + 1, Text: "first"}, {Offset: b, End: b + 1, Text: "second"}},
+			"package main\n\nfunc first() {}\n\nfunc second() {}\n",
+			false,
+		},
+		{
+			"reversed",
+			[]Edit{{Offset: b, End: b + 1, Text: "second"}, {Offset: a, End: a + 1, Text: "first"}},
+			"package main\n\nfunc first() {}\n\nfunc second() {}\n",
+			false,
+		},
+		{
+			"the same edit twice is applied once",
+			[]Edit{{Offset: a, End: a + 1, Text: "first"}, {Offset: a, End: a + 1, Text: "first"}},
+			"package main\n\nfunc first() {}\n\nfunc b() {}\n",
+			false,
+		},
+		{
+			"insertion",
+			[]Edit{{Offset: a, End: a, Text: "x"}},
+			"package main\n\nfunc xa() {}\n\nfunc b() {}\n",
+			false,
+		},
+		{
+			"gofmted after",
+			[]Edit{{Offset: body, End: body, Text: "\nreturn   \n"}},
+			"package main\n\nfunc a() {\n\treturn\n}\n\nfunc b() {}\n",
+			false,
+		},
+		{"overlapping", []Edit{{Offset: a, End: a + 5, Text: "x"}, {Offset: a + 2, End: a + 3, Text: "y"}}, src, true},
+		{"outside the file", []Edit{{Offset: len(src), End: len(src) + 1, Text: "x"}}, src, true},
+		{"backwards", []Edit{{Offset: b, End: a, Text: "x"}}, src, true},
+		{"does not parse", []Edit{{Offset: a, End: a + 1, Text: "{"}}, src, true},
+	}
+	for _, test := range tests {
+		name := filepath.Join(t.TempDir(), "fix.go");
+		if err := os.WriteFile(name, []byte(src), 0644); err != nil {
+			t.Fatal(err);
+		}
+		for i := range test.edits {
+			test.edits[i].File = name;
+		}
+		err := fixFile(name, test.edits);
+		if (err != nil) != test.err {
+			t.Errorf("%s: error = %v, want error %v", test.name, err, test.err);
+			continue;
+		}
+		got, err := os.ReadFile(name);
+		if err != nil {
+			t.Fatal(err);
+		}
+		// a failed fix leaves the file alone
+		if string(got) != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got, test.want);
+		}
+	}
+}
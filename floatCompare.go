@@ -0,0 +1,55 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("floatCompare",
+		"this tests for floating point values compared with == or !=",
+		severityLow,
+		categoryCorrectness,
+		floatCompareCheck,
+		binaryExpr)
+}
+
+// isFloat checks if a type is a floating point type
+func isFloat(t types.Type) bool {
+	if t == nil {
+		return false;
+	}
+	basic, ok := t.Underlying().(*types.Basic);
+	return ok && basic.Info()&types.IsFloat != 0;
+}
+
+// isZero checks if an expression is the constant 0
+func isZero(f *File, x ast.Expr) bool {
+	tv, ok := f.pkg.info.Types[x];
+	return ok && tv.Value != nil && constant.Sign(tv.Value) == 0;
+}
+
+func floatCompareCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return;
+	}
+	if !isFloat(f.pkg.info.TypeOf(expr.X)) || !isFloat(f.pkg.info.TypeOf(expr.Y)) {
+		return;
+	}
+	// constant folded by the compiler
+	if isConst(f, expr.X) && isConst(f, expr.Y) {
+		return;
+	}
+	if isZero(f, expr.X) || isZero(f, expr.Y) {
+		f.Reportf(expr.Pos(), "floating point compared to zero with %s, fine if the value is assigned not computed: %s", expr.Op, f.ASTString(expr));
+		return;
+	}
+	f.Reportf(expr.Pos(), "floating point compared with %s, compare the difference to an epsilon: %s", expr.Op, f.ASTString(expr));
+	return;
+}
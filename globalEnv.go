@@ -0,0 +1,61 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+)
+
+var globalEnvs = flag.Bool("global-env", false, "report package level variables initialized from the environment")
+
+func init() {
+	registerOptIn("globalEnv",
+		"this tests for package level variables initialized from os.Getenv",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *globalEnvs;
+		},
+		globalEnvCheck,
+		genDecl)
+}
+
+// readsEnv returns the first os.Getenv or os.LookupEnv call in x
+func readsEnv(f *File, x ast.Expr) *ast.CallExpr {
+	var found *ast.CallExpr;
+	ast.Inspect(x, func(node ast.Node) bool {
+		// function literals run later, not at init
+		if _, ok := node.(*ast.FuncLit); ok {
+			return false;
+		}
+		if call, ok := node.(*ast.CallExpr); ok && found == nil {
+			if path, name := f.getPkgFunc(call); path == "os" && (name == "Getenv" || name == "LookupEnv") {
+				found = call;
+			}
+		}
+		return found == nil;
+	})
+	return found;
+}
+
+func globalEnvCheck(f *File, node ast.Node) {
+	decl, ok := node.(*ast.GenDecl);
+	if !ok || decl.Tok != token.VAR || f.enclosingFunc() != nil {
+		return;
+	}
+	for _, spec := range decl.Specs {
+		vspec, ok := spec.(*ast.ValueSpec);
+		if !ok {
+			continue;
+		}
+		for _, value := range vspec.Values {
+			if call := readsEnv(f, value); call != nil {
+				f.Reportf(call.Pos(), "package level variable read from the environment at init, read config in main or a constructor: %s", f.ASTString(call));
+			}
+		}
+	}
+	return;
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("goLoop",
+		"this tests for goroutines started in unbounded loops without a concurrency limit",
+		severityLow,
+		categoryCorrectness,
+		goLoopCheck,
+		goStmt)
+}
+
+// boundedLoop checks if a loop runs a fixed number of times rather than once
+// for each item of some data, i.e. for i := 0; i < 10; i++, a worker pool's
+// for i := 0; i < workers; i++ or ranging over an array
+func boundedLoop(f *File, loop ast.Node) bool {
+	switch l := loop.(type) {
+	case *ast.ForStmt:
+		cond, ok := l.Cond.(*ast.BinaryExpr);
+		if !ok {
+			return false;
+		}
+		switch cond.Op {
+		case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return false;
+		}
+		// i < len(items) goes through data like a range loop
+		if call, ok := ast.Unparen(cond.Y).(*ast.CallExpr); ok && (isBuiltin(f, call, "len") || isBuiltin(f, call, "cap")) {
+			return false;
+		}
+		return true;
+	case *ast.RangeStmt:
+		if isConst(f, l.X) {
+			return true;
+		}
+		t := f.pkg.info.TypeOf(l.X);
+		if t == nil {
+			return false;
+		}
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem();
+		}
+		_, ok := t.Underlying().(*types.Array);
+		return ok;
+	}
+	return false;
+}
+
+// limitsConcurrency looks for a worker limit in a function body,
+// a buffered channel used as a semaphore, errgroup.SetLimit
+// or a semaphore's Acquire
+func limitsConcurrency(f *File, body *ast.BlockStmt) bool {
+	found := false;
+	ast.Inspect(body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr);
+		if !ok {
+			return !found;
+		}
+		if isBuiltin(f, call, "make") && len(call.Args) == 2 {
+			if _, ok := call.Args[0].(*ast.ChanType); ok {
+				found = true;
+			}
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "SetLimit" || sel.Sel.Name == "Acquire") {
+			found = true;
+		}
+		return !found;
+	})
+	return found;
+}
+
+func goLoopCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.GoStmt);
+	if !ok {
+		return;
+	}
+	loop := f.enclosingLoop();
+	if loop == nil || boundedLoop(f, loop) {
+		return;
+	}
+	var body *ast.BlockStmt;
+	switch fun := f.enclosingFunc().(type) {
+	case *ast.FuncDecl:
+		body = fun.Body;
+	case *ast.FuncLit:
+		body = fun.Body;
+	}
+	if body != nil && limitsConcurrency(f, body) {
+		return;
+	}
+	f.Reportf(stmt.Pos(), "goroutine per iteration of an unbounded loop, limit concurrency with a worker pool or semaphore");
+	return;
+}
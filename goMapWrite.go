@@ -0,0 +1,120 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("goMapWrite",
+		"this tests for maps written from goroutines and elsewhere without a lock",
+		severityHigh,
+		categoryCorrectness,
+		goMapWriteCheck,
+		goStmt)
+}
+
+// mapObj returns the variable or field of a map valued expression, m or s.m
+func mapObj(f *File, x ast.Expr) types.Object {
+	t := f.pkg.info.TypeOf(x);
+	if t == nil {
+		return nil;
+	}
+	if _, ok := t.Underlying().(*types.Map); !ok {
+		return nil;
+	}
+	switch x := x.(type) {
+	case *ast.Ident:
+		return f.pkg.info.ObjectOf(x);
+	case *ast.SelectorExpr:
+		return f.pkg.info.ObjectOf(x.Sel);
+	}
+	return nil;
+}
+
+// mapWrites finds the maps written in node, as m[k] = v, m[k]++ or delete(m, k)
+// skipping anything inside skip
+func mapWrites(f *File, node ast.Node, skip ast.Node) map[types.Object]token.Pos {
+	writes := make(map[types.Object]token.Pos);
+	add := func(x ast.Expr) {
+		if obj := mapObj(f, x); obj != nil {
+			if _, ok := writes[obj]; !ok {
+				writes[obj] = x.Pos();
+			}
+		}
+	}
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == nil || n == skip {
+			return false;
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if index, ok := lhs.(*ast.IndexExpr); ok {
+					add(index.X);
+				}
+			}
+		case *ast.IncDecStmt:
+			if index, ok := n.X.(*ast.IndexExpr); ok {
+				add(index.X);
+			}
+		case *ast.CallExpr:
+			if isBuiltin(f, n, "delete") && len(n.Args) == 2 {
+				add(n.Args[0]);
+			}
+		}
+		return true;
+	})
+	return writes;
+}
+
+// locks checks for a Lock call in node, taken to guard the maps it writes
+func locks(node ast.Node) bool {
+	found := false;
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Lock" {
+				found = true;
+			}
+		}
+		return !found;
+	})
+	return found;
+}
+
+func goMapWriteCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.GoStmt);
+	if !ok {
+		return;
+	}
+	lit, ok := stmt.Call.Fun.(*ast.FuncLit);
+	if !ok || locks(lit.Body) {
+		return;
+	}
+	// writes anywhere in the top level function count, not just the innermost
+	var outer ast.Node
+	if decl := f.enclosingFuncDecl(); decl != nil {
+		outer = decl;
+	} else {
+		outer = f.enclosingFunc();
+	}
+	if outer == nil {
+		return;
+	}
+	inLoop := f.enclosingLoop() != nil;
+	elsewhere := mapWrites(f, outer, lit);
+	for obj, pos := range mapWrites(f, lit.Body, nil) {
+		// maps made inside the goroutine are its own
+		if obj.Pos() >= lit.Pos() && obj.Pos() < lit.End() {
+			continue;
+		}
+		if _, ok := elsewhere[obj]; ok || inLoop {
+			f.Reportf(pos, "map %s written from a goroutine without a lock, guard it with a sync.Mutex or use sync.Map", obj.Name());
+		}
+	}
+	return;
+}
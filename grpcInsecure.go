@@ -0,0 +1,30 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("grpcInsecure",
+		"this tests for gRPC connections without transport security",
+		severityHigh,
+		categorySecurity,
+		grpcInsecureCheck,
+		callExpr)
+}
+
+func grpcInsecureCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	if (path == "google.golang.org/grpc" && name == "WithInsecure") ||
+		(path == "google.golang.org/grpc/credentials/insecure" && name == "NewCredentials") {
+		f.Reportf(call.Pos(), "unencrypted gRPC connection %s, use TLS credentials in production", f.ASTString(call));
+	}
+	return;
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+)
+
+var secretRegex = flag.String("secret-regex", "", "extra regular expression for hardcodedToken to report string literals matching, e.g. ghp_[A-Za-z0-9]{36}")
+
+func init() {
+	register("hardcodedToken",
+		"this tests for bearer tokens, basic auth credentials and AWS keys in string literals",
+		severityHigh,
+		categorySecurity,
+		hardcodedTokenCheck,
+		basicLit)
+}
+
+// tokenPattern is a kind of credential and what it looks like
+type tokenPattern struct {
+	kind	string
+	re	*regexp.Regexp
+}
+
+var tokenPatterns = []tokenPattern{
+	{"bearer token", regexp.MustCompile(`(?i)bearer\s+[a-z0-9._-]{20,}`)},
+	{"basic auth credentials", regexp.MustCompile(`Basic [A-Za-z0-9+/=]{16,}`)},
+	{"AWS access key", regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+}
+
+// loadSecretRegex adds the -secret-regex pattern to the token patterns
+func loadSecretRegex() error {
+	if *secretRegex == "" {
+		return nil;
+	}
+	re, err := regexp.Compile(*secretRegex);
+	if err != nil {
+		return fmt.Errorf("bad -secret-regex: %s", err);
+	}
+	tokenPatterns = append(tokenPatterns, tokenPattern{"secret matching -secret-regex", re});
+	return nil;
+}
+
+func hardcodedTokenCheck(f *File, node ast.Node) {
+	lit, ok := node.(*ast.BasicLit);
+	if !ok || lit.Kind != token.STRING {
+		return;
+	}
+	value, err := strconv.Unquote(lit.Value);
+	if err != nil {
+		return;
+	}
+	for _, pattern := range tokenPatterns {
+		// the literal is not printed so the credential isn't copied into logs
+		if pattern.re.MatchString(value) {
+			f.Reportf(lit.Pos(), "hardcoded %s in a string literal, load it from the environment or a secret store", pattern.kind);
+			return;
+		}
+	}
+	return;
+}
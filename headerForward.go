@@ -0,0 +1,84 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"net/http"
+)
+
+// headers carrying the incoming caller's credentials
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+func init() {
+	register("headerForward",
+		"this tests for credential headers copied from one http request to another",
+		severityHigh,
+		categorySecurity,
+		headerForwardCheck,
+		callExpr)
+}
+
+// requestHeader returns the request expression of req.Header.Method(...)
+// when req is an *http.Request, or nil
+func requestHeader(f *File, call *ast.CallExpr, methods ...string) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return nil;
+	}
+	found := false;
+	for _, method := range methods {
+		if sel.Sel.Name == method {
+			found = true;
+		}
+	}
+	header, ok := sel.X.(*ast.SelectorExpr);
+	if !found || !ok || header.Sel.Name != "Header" {
+		return nil;
+	}
+	if t := f.pkg.info.TypeOf(header.X); t == nil || t.String() != "*net/http.Request" {
+		return nil;
+	}
+	return header.X;
+}
+
+// sensitiveHeader returns the canonical name of a credential header
+func sensitiveHeader(f *File, x ast.Expr) (string, bool) {
+	name, ok := constString(f, x);
+	if !ok {
+		return "", false;
+	}
+	name = http.CanonicalHeaderKey(name);
+	for _, sensitive := range sensitiveHeaders {
+		if name == sensitive {
+			return name, true;
+		}
+	}
+	return "", false;
+}
+
+func headerForwardCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) != 2 {
+		return;
+	}
+	out := requestHeader(f, call, "Set", "Add");
+	if out == nil {
+		return;
+	}
+	name, ok := sensitiveHeader(f, call.Args[0]);
+	if !ok {
+		return;
+	}
+	get, ok := call.Args[1].(*ast.CallExpr);
+	if !ok || len(get.Args) != 1 {
+		return;
+	}
+	in := requestHeader(f, get, "Get");
+	if in == nil || f.ASTString(in) == f.ASTString(out) {
+		return;
+	}
+	f.Reportf(call.Pos(), "%s header forwarded from %s to %s leaks the caller's credentials: %s", name, f.ASTString(in), f.ASTString(out), f.ASTString(call));
+	return;
+}
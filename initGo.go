@@ -0,0 +1,28 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("initGo",
+		"this tests for goroutines started in init functions",
+		severityLow,
+		categoryCorrectness,
+		initGoCheck,
+		goStmt)
+}
+
+func initGoCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.GoStmt);
+	if !ok {
+		return;
+	}
+	if fun := f.enclosingFuncDecl(); fun != nil && fun.Recv == nil && fun.Name.Name == "init" {
+		f.Reportf(stmt.Pos(), "goroutine started in init before main runs: %s", f.ASTString(stmt.Call));
+	}
+	return;
+}
@@ -11,6 +11,8 @@ import (
 func init() {
 	register("insecureCrypto",
 		"this test checks for insecure cryptography primitives",
+		severityHigh,
+		categorySecurity,
 		cryptoCheck,
 		fileNode)
 }
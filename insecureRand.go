@@ -10,6 +10,8 @@ import (
 func init() {
 	register("insecureRand",
 		"this is test to check if random nums generated insecurely",
+		severityMedium,
+		categorySecurity,
 		randCheck,
 		fileNode)
 }
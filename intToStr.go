@@ -12,6 +12,8 @@ import (
 func init() {
 	register("intToStr",
 		"check if integers are being converted to strings using string()",
+		severityLow,
+		categoryCorrectness,
 		intToStrCheck,
 		callExpr)
 }
@@ -0,0 +1,44 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+)
+
+var (
+	interfaceSize = flag.Bool("interface-size", false, "report interfaces with more than -max-interface-methods methods")
+	maxInterfaceMethods = flag.Int("max-interface-methods", 5, "the most methods an interface may declare")
+)
+
+func init() {
+	registerOptIn("interfaceSize",
+		"this tests for interfaces declaring too many methods",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *interfaceSize;
+		},
+		interfaceSizeCheck,
+		interfaceType)
+}
+
+func interfaceSizeCheck(f *File, node ast.Node) {
+	iface, ok := node.(*ast.InterfaceType);
+	if !ok || iface.Methods == nil {
+		return;
+	}
+	// embedded interfaces have no names and are not counted
+	methods := 0;
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			methods += len(field.Names);
+		}
+	}
+	if methods > *maxInterfaceMethods {
+		f.Reportf(iface.Pos(), "interface declares %d methods, more than %d, consider smaller interfaces", methods, *maxInterfaceMethods);
+	}
+	return;
+}
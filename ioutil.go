@@ -0,0 +1,143 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ioutilReplacements maps deprecated io/ioutil functions to their replacements
+// ReadDir is left out, os.ReadDir returns fs.DirEntry values not os.FileInfo
+var ioutilReplacements = map[string][2]string{
+	"ReadAll":	{"io", "ReadAll"},
+	"NopCloser":	{"io", "NopCloser"},
+	"Discard":	{"io", "Discard"},
+	"ReadFile":	{"os", "ReadFile"},
+	"WriteFile":	{"os", "WriteFile"},
+	"TempFile":	{"os", "CreateTemp"},
+	"TempDir":	{"os", "MkdirTemp"},
+}
+
+func init() {
+	register("ioutil",
+		"this tests for the deprecated io/ioutil package, fixable with -fix",
+		severityLow,
+		categoryStyle,
+		ioutilCheck,
+		fileNode)
+}
+
+// importSpec returns the import of path in a file and the declaration holding it
+func importSpec(file *ast.File, path string) (*ast.GenDecl, *ast.ImportSpec) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl);
+		if !ok {
+			continue;
+		}
+		for _, spec := range gen.Specs {
+			if imp, ok := spec.(*ast.ImportSpec); ok && strings.Trim(imp.Path.Value, "`\"") == path {
+				return gen, imp;
+			}
+		}
+	}
+	return nil, nil;
+}
+
+// pkgNameAt checks that name refers to the import of path at sel,
+// or to nothing so the import can be added, and is not shadowed
+func pkgNameAt(f *File, sel *ast.SelectorExpr, name, path string) bool {
+	if f.pkg.typePkg == nil {
+		return false;
+	}
+	scope := f.pkg.typePkg.Scope().Innermost(sel.Pos());
+	if scope == nil {
+		return false;
+	}
+	_, obj := scope.LookupParent(name, sel.Pos());
+	if obj == nil {
+		return true;
+	}
+	pkgName, ok := obj.(*types.PkgName);
+	return ok && pkgName.Imported().Path() == path;
+}
+
+// importEdit rewrites the io/ioutil import to the list of paths
+func importEdit(f *File, gen *ast.GenDecl, spec *ast.ImportSpec, paths []string) Edit {
+	var quoted []string;
+	for _, path := range paths {
+		quoted = append(quoted, strconv.Quote(path));
+	}
+	if gen.Lparen.IsValid() {
+		return f.edit(spec.Pos(), spec.End(), strings.Join(quoted, "\n\t"));
+	}
+	switch len(quoted) {
+	case 0:
+		return f.edit(gen.Pos(), gen.End(), "");
+	case 1:
+		return f.edit(gen.Pos(), gen.End(), "import " + quoted[0]);
+	}
+	return f.edit(gen.Pos(), gen.End(), "import (\n\t" + strings.Join(quoted, "\n\t") + "\n)");
+}
+
+func ioutilCheck(f *File, node ast.Node) {
+	file, ok := node.(*ast.File);
+	if !ok {
+		return;
+	}
+	gen, spec := importSpec(file, "io/ioutil");
+	if spec == nil {
+		return;
+	}
+	// renamed and dot imports are reported but not fixed
+	fixable := spec.Name == nil;
+	type use struct {
+		sel	*ast.SelectorExpr
+		fix	[]Edit
+	}
+	var uses []use;
+	needed := make(map[string]bool);
+	keepIoutil := false;
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr);
+		if !ok || f.importPath(sel.X) != "io/ioutil" {
+			return true;
+		}
+		u := use{sel: sel};
+		repl, ok := ioutilReplacements[sel.Sel.Name];
+		// a suppressed use is never reported, so is never fixed and still needs io/ioutil
+		if ok && fixable && !f.suppressed(sel.Pos()) && pkgNameAt(f, sel, repl[0], repl[0]) {
+			u.fix = []Edit{f.edit(sel.Pos(), sel.End(), repl[0] + "." + repl[1])};
+			needed[repl[0]] = true;
+		} else {
+			keepIoutil = true;
+		}
+		uses = append(uses, u);
+		return false;
+	})
+	// the import changes once, carried by every fixed use
+	var paths []string;
+	for path := range needed {
+		if _, imp := importSpec(file, path); imp == nil {
+			paths = append(paths, path);
+		}
+	}
+	if keepIoutil {
+		paths = append(paths, "io/ioutil");
+	}
+	sort.Strings(paths);
+	imports := importEdit(f, gen, spec, paths);
+	for _, u := range uses {
+		if u.fix == nil {
+			f.Reportf(u.sel.Pos(), "io/ioutil is deprecated: %s", f.ASTString(u.sel));
+			continue;
+		}
+		repl := ioutilReplacements[u.sel.Sel.Name];
+		f.ReportFix(u.sel.Pos(), append(u.fix, imports), "io/ioutil is deprecated, use %s.%s: %s", repl[0], repl[1], f.ASTString(u.sel));
+	}
+	return;
+}
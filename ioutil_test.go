@@ -0,0 +1,48 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFixGolden applies a checker's fixes to each testdata/fix/*.input file
+// and compares the result with the matching .golden file
+func TestFixGolden(t *testing.T) {
+	tests := []struct {
+		name	string
+		checker	string
+	}{
+		{"ioutil", "ioutil"},
+		{"ioutilIgnored", "ioutil"},
+		{"errorString", "errorString"},
+	}
+	for _, test := range tests {
+		name := test.name;
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", "fix", name+".input"));
+			if err != nil {
+				t.Fatal(err);
+			}
+			want, err := os.ReadFile(filepath.Join("testdata", "fix", name+".golden"));
+			if err != nil {
+				t.Fatal(err);
+			}
+			path := filepath.Join(t.TempDir(), name+".go");
+			if err := os.WriteFile(path, src, 0644); err != nil {
+				t.Fatal(err);
+			}
+			applyFixes(runCheckers(t, test.checker, path));
+			got, err := os.ReadFile(path);
+			if err != nil {
+				t.Fatal(err);
+			}
+			if string(got) != string(want) {
+				t.Errorf("fixed %s:\n%s\nwant:\n%s", name, got, want);
+			}
+		});
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("jsonError",
+		"this tests for errors from json.Marshal and json.Unmarshal dropped or assigned to _",
+		severityMedium,
+		categoryCorrectness,
+		jsonErrorCheck,
+		assignStmt,
+		exprStmt)
+}
+
+// jsonErrorHints say what goes wrong when each function's error is dropped
+var jsonErrorHints = map[string]string{
+	"Marshal":		"unsupported values such as channels and NaN give nil output",
+	"MarshalIndent":	"unsupported values such as channels and NaN give nil output",
+	"Unmarshal":		"bad input leaves the target partly filled in",
+}
+
+// jsonCall returns the name of a json.Marshal or json.Unmarshal call or ""
+func jsonCall(f *File, x ast.Expr) string {
+	call, ok := x.(*ast.CallExpr);
+	if !ok {
+		return "";
+	}
+	path, name := f.getPkgFunc(call);
+	if _, ok := jsonErrorHints[name]; !ok || path != "encoding/json" {
+		return "";
+	}
+	return name;
+}
+
+func jsonErrorCheck(f *File, node ast.Node) {
+	switch stmt := node.(type) {
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) != 1 {
+			return;
+		}
+		name := jsonCall(f, stmt.Rhs[0]);
+		if name == "" {
+			return;
+		}
+		// the error is the last result
+		if id, ok := stmt.Lhs[len(stmt.Lhs)-1].(*ast.Ident); ok && id.Name == "_" {
+			f.Reportf(stmt.Pos(), "json.%s error assigned to _, %s: %s", name, jsonErrorHints[name], f.ASTString(stmt.Rhs[0]));
+		}
+	case *ast.ExprStmt:
+		if name := jsonCall(f, stmt.X); name != "" {
+			f.Reportf(stmt.Pos(), "json.%s error dropped, %s: %s", name, jsonErrorHints[name], f.ASTString(stmt.X));
+		}
+	}
+	return;
+}
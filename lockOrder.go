@@ -0,0 +1,101 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("lockOrder",
+		"this tests for mutexes locked in opposite orders which can deadlock",
+		severityHigh,
+		categoryCorrectness,
+		lockOrderCheck,
+		funcDecl)
+}
+
+// lockPair is a mutex locked while another one is held
+type lockPair struct {
+	held, locked types.Object
+}
+
+// mutexObj returns the variable or field holding the mutex x
+// so s.mu in two different methods is the same mutex
+func mutexObj(f *File, x ast.Expr) types.Object {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return f.pkg.info.ObjectOf(x);
+	case *ast.SelectorExpr:
+		if selection, ok := f.pkg.info.Selections[x]; ok && selection.Kind() == types.FieldVal {
+			return selection.Obj();
+		}
+	}
+	return nil;
+}
+
+// lockCall returns the mutex locked or unlocked by a call
+func lockCall(f *File, call *ast.CallExpr) (mutex types.Object, lock bool) {
+	switch f.getMethod(call) {
+	case "(*sync.Mutex).Lock", "(*sync.RWMutex).Lock", "(*sync.RWMutex).RLock":
+		lock = true;
+	case "(*sync.Mutex).Unlock", "(*sync.RWMutex).Unlock", "(*sync.RWMutex).RUnlock":
+		lock = false;
+	default:
+		return nil, false;
+	}
+	return mutexObj(f, call.Fun.(*ast.SelectorExpr).X), lock;
+}
+
+func lockOrderCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	// orders are kept on the package so functions in different files are compared
+	if f.pkg.lockOrders == nil {
+		f.pkg.lockOrders = make(map[lockPair]token.Pos);
+	}
+	orders := f.pkg.lockOrders;
+	var held []types.Object;
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false;
+		case *ast.DeferStmt:
+			// deferred unlocks keep the mutex held to the end
+			return false;
+		case *ast.CallExpr:
+			mutex, lock := lockCall(f, n);
+			if mutex == nil {
+				return true;
+			}
+			if !lock {
+				for i, h := range held {
+					if h == mutex {
+						held = append(held[:i], held[i+1:]...);
+						break;
+					}
+				}
+				return true;
+			}
+			for _, h := range held {
+				if h == mutex {
+					continue;
+				}
+				if pos, ok := orders[lockPair{mutex, h}]; ok {
+					f.Reportf(n.Pos(), "%s locked while holding %s, the opposite order is used at %s, possible deadlock", mutex.Name(), h.Name(), f.loc(pos));
+				}
+				if _, ok := orders[lockPair{h, mutex}]; !ok {
+					orders[lockPair{h, mutex}] = n.Pos();
+				}
+			}
+			held = append(held, mutex);
+		}
+		return true;
+	})
+	return;
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("lockReturn",
+		"this tests for returns while a mutex is locked without a deferred unlock",
+		severityHigh,
+		categoryCorrectness,
+		lockReturnCheck,
+		returnStmt)
+}
+
+// deferredUnlocks returns the mutexes a defer statement unlocks
+// either defer mu.Unlock() or defer func() { mu.Unlock() }()
+func deferredUnlocks(f *File, stmt *ast.DeferStmt) []types.Object {
+	var mutexes []types.Object;
+	ast.Inspect(stmt.Call, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok {
+			if mutex, lock := lockCall(f, call); mutex != nil && !lock {
+				mutexes = append(mutexes, mutex);
+			}
+		}
+		return true;
+	})
+	return mutexes;
+}
+
+func lockReturnCheck(f *File, node ast.Node) {
+	ret, ok := node.(*ast.ReturnStmt);
+	if !ok {
+		return;
+	}
+	var body *ast.BlockStmt;
+	switch fun := f.enclosingFunc().(type) {
+	case *ast.FuncDecl:
+		body = fun.Body;
+	case *ast.FuncLit:
+		body = fun.Body;
+	}
+	if body == nil {
+		return;
+	}
+	// walk the function in order up to the return, the lock held
+	// is the last Lock not yet followed by an Unlock
+	held := make(map[types.Object]*ast.CallExpr);
+	deferred := make(map[types.Object]bool);
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil || node.Pos() >= ret.Pos() {
+			return false;
+		}
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			// other functions lock on their own
+			return false;
+		case *ast.DeferStmt:
+			for _, mutex := range deferredUnlocks(f, n) {
+				deferred[mutex] = true;
+			}
+			return false;
+		case *ast.CallExpr:
+			mutex, lock := lockCall(f, n);
+			if mutex == nil {
+				return true;
+			}
+			if lock {
+				held[mutex] = n;
+			} else {
+				delete(held, mutex);
+			}
+		}
+		return true;
+	})
+	for mutex, lock := range held {
+		if deferred[mutex] {
+			continue;
+		}
+		f.Reportf(ret.Pos(), "return while %s is locked at %s and never unlocked, unlock first or defer the unlock", f.ASTString(lock.Fun.(*ast.SelectorExpr).X), f.loc(lock.Pos()));
+	}
+	return;
+}
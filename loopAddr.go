@@ -0,0 +1,102 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("loopAddr",
+		"this tests for the address of a loop variable escaping the loop, shared by every iteration before Go 1.22",
+		severityMedium,
+		categoryCorrectness,
+		loopAddrCheck,
+		rangeStmt,
+		forStmt)
+}
+
+// loopVars returns the variables declared by a for or range statement
+func loopVars(f *File, node ast.Node) map[types.Object]bool {
+	vars := make(map[types.Object]bool);
+	var idents []ast.Expr;
+	switch loop := node.(type) {
+	case *ast.RangeStmt:
+		if loop.Tok == token.DEFINE {
+			idents = append(idents, loop.Key, loop.Value);
+		}
+	case *ast.ForStmt:
+		if init, ok := loop.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			idents = append(idents, init.Lhs...);
+		}
+	}
+	for _, x := range idents {
+		if id, ok := x.(*ast.Ident); ok {
+			if obj := f.pkg.info.Defs[id]; obj != nil {
+				vars[obj] = true;
+			}
+		}
+	}
+	return vars;
+}
+
+// loopVarAddr returns the loop variable if x is &v for a loop variable v
+func loopVarAddr(f *File, x ast.Expr, vars map[types.Object]bool) types.Object {
+	unary, ok := x.(*ast.UnaryExpr);
+	if !ok || unary.Op != token.AND {
+		return nil;
+	}
+	if id, ok := unary.X.(*ast.Ident); ok && vars[f.pkg.info.ObjectOf(id)] {
+		return f.pkg.info.ObjectOf(id);
+	}
+	return nil;
+}
+
+func loopAddrCheck(f *File, node ast.Node) {
+	var body *ast.BlockStmt;
+	switch loop := node.(type) {
+	case *ast.RangeStmt:
+		body = loop.Body;
+	case *ast.ForStmt:
+		body = loop.Body;
+	default:
+		return;
+	}
+	vars := loopVars(f, node);
+	if len(vars) == 0 || perIterationLoops(f) {
+		return;
+	}
+	// the places &v outlives the iteration
+	var escaping []ast.Expr;
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false;
+		case *ast.ReturnStmt:
+			escaping = append(escaping, n.Results...);
+		case *ast.AssignStmt:
+			escaping = append(escaping, n.Rhs...);
+		case *ast.CallExpr:
+			if isBuiltin(f, n, "append") {
+				escaping = append(escaping, n.Args...);
+			}
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value;
+				}
+				escaping = append(escaping, elt);
+			}
+		}
+		return true;
+	})
+	for _, x := range escaping {
+		if obj := loopVarAddr(f, x, vars); obj != nil {
+			f.Reportf(x.Pos(), "address of loop variable %s escapes the loop, before Go 1.22 every iteration shares it", obj.Name());
+		}
+	}
+	return;
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bufio"
+	"go/ast"
+	"go/types"
+	"go/version"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+func init() {
+	register("loopClosure",
+		"this tests for go and defer closures in a loop using the loop variable, shared by every iteration before Go 1.22",
+		severityMedium,
+		categoryCorrectness,
+		loopClosureCheck,
+		funcLit)
+}
+
+// goVersions caches the go directive of the go.mod governing each directory
+// it is shared by loopClosure and loopAddr, which may run past -checker-timeout
+var (
+	goVersions	= make(map[string]string)
+	goVersionsMu	sync.Mutex
+)
+
+// moduleGoVersion returns the go version from the go.mod file in dir or
+// the nearest directory above it, like "go1.21", or "" without one
+func moduleGoVersion(dir string) string {
+	goVersionsMu.Lock();
+	defer goVersionsMu.Unlock();
+	return readGoVersion(dir);
+}
+
+// readGoVersion does the work of moduleGoVersion, the caller holds goVersionsMu
+func readGoVersion(dir string) string {
+	if v, ok := goVersions[dir]; ok {
+		return v;
+	}
+	v := "";
+	if file, err := os.Open(filepath.Join(dir, "go.mod")); err == nil {
+		scanner := bufio.NewScanner(file);
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text());
+			if len(fields) == 2 && fields[0] == "go" {
+				v = "go" + fields[1];
+				break;
+			}
+		}
+		file.Close();
+	} else if parent := filepath.Dir(dir); parent != dir {
+		v = readGoVersion(parent);
+	}
+	goVersions[dir] = v;
+	return v;
+}
+
+// perIterationLoops checks if the file is in a module built with Go 1.22
+// or later, where each iteration of a loop has its own variables
+func perIterationLoops(f *File) bool {
+	dir, err := filepath.Abs(filepath.Dir(f.name));
+	if err != nil {
+		return false;
+	}
+	v := moduleGoVersion(dir);
+	return version.IsValid(v) && version.Compare(v, "go1.22") >= 0;
+}
+
+func loopClosureCheck(f *File, node ast.Node) {
+	lit, ok := node.(*ast.FuncLit);
+	n := len(f.stack);
+	if !ok || n < 2 {
+		return;
+	}
+	// go func() {...}() or defer func() {...}()
+	call, ok := f.stack[n-1].(*ast.CallExpr);
+	if !ok || call.Fun != lit {
+		return;
+	}
+	stmt := "go";
+	switch f.stack[n-2].(type) {
+	case *ast.GoStmt:
+	case *ast.DeferStmt:
+		stmt = "defer";
+	default:
+		return;
+	}
+	// variables of every loop around the statement in the same function
+	vars := make(map[types.Object]bool);
+loops:
+	for i := n - 1; i >= 0; i-- {
+		switch loop := f.stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			for obj := range loopVars(f, loop) {
+				vars[obj] = true;
+			}
+		case *ast.FuncDecl, *ast.FuncLit:
+			break loops;
+		}
+	}
+	if len(vars) == 0 || perIterationLoops(f) {
+		return;
+	}
+	reported := make(map[types.Object]bool);
+	ast.Inspect(lit.Body, func(node ast.Node) bool {
+		id, ok := node.(*ast.Ident);
+		if !ok {
+			return true;
+		}
+		obj := f.pkg.info.Uses[id];
+		if vars[obj] && !reported[obj] {
+			reported[obj] = true;
+			f.Reportf(id.Pos(), "%s closure uses loop variable %s, which every iteration shares before Go 1.22, pass it as an argument", stmt, id.Name);
+		}
+		return true;
+	});
+	return;
+}
@@ -0,0 +1,204 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("loopConvert",
+		"this tests for string and []byte conversions of the same value on every loop iteration",
+		severityLow,
+		categoryPerformance,
+		loopConvertCheck,
+		forStmt, rangeStmt)
+}
+
+// isBytes checks for a []byte type
+func isBytes(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice);
+	if !ok {
+		return false;
+	}
+	elem, ok := slice.Elem().Underlying().(*types.Basic);
+	return ok && elem.Kind() == types.Byte;
+}
+
+// isString checks for a string type
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic);
+	return ok && basic.Info()&types.IsString != 0;
+}
+
+// bytesConversion checks for string(b) or []byte(s), which copy and allocate
+func bytesConversion(f *File, call *ast.CallExpr) bool {
+	if len(call.Args) != 1 {
+		return false;
+	}
+	tv, ok := f.pkg.info.Types[call.Fun];
+	from := f.pkg.info.TypeOf(call.Args[0]);
+	if !ok || !tv.IsType() || from == nil {
+		return false;
+	}
+	return (isString(tv.Type) && isBytes(from)) || (isBytes(tv.Type) && isString(from));
+}
+
+// rootIdent returns the variable at the base of x.f, x[i] or *x
+func rootIdent(x ast.Expr) *ast.Ident {
+	for {
+		switch e := x.(type) {
+		case *ast.Ident:
+			return e;
+		case *ast.SelectorExpr:
+			x = e.X;
+		case *ast.IndexExpr:
+			x = e.X;
+		case *ast.SliceExpr:
+			x = e.X;
+		case *ast.StarExpr:
+			x = e.X;
+		case *ast.ParenExpr:
+			x = e.X;
+		default:
+			return nil;
+		}
+	}
+}
+
+// loopVaried returns the variables that may change from one iteration
+// of loop to the next: those declared or assigned in it, and those
+// passed to calls or having their address taken, since their contents
+// may be changed through them
+func loopVaried(f *File, loop ast.Node) map[types.Object]bool {
+	varied := make(map[types.Object]bool);
+	mark := func(x ast.Expr) {
+		if id := rootIdent(x); id != nil {
+			if obj := f.pkg.info.ObjectOf(id); obj != nil {
+				varied[obj] = true;
+			}
+		}
+	}
+	ast.Inspect(loop, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Ident:
+			if obj, ok := f.pkg.info.Defs[n]; ok && obj != nil {
+				varied[obj] = true;
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				mark(lhs);
+			}
+		case *ast.IncDecStmt:
+			mark(n.X);
+		case *ast.RangeStmt:
+			if n.Key != nil {
+				mark(n.Key);
+			}
+			if n.Value != nil {
+				mark(n.Value);
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				mark(n.X);
+			}
+		case *ast.CallExpr:
+			if bytesConversion(f, n) {
+				return true;
+			}
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				mark(sel.X);
+			}
+			for _, arg := range n.Args {
+				mark(arg);
+			}
+		}
+		return true;
+	});
+	return varied;
+}
+
+// invariant checks if x has the same value on every iteration,
+// it only refers to constants and variables that are not varied
+func invariant(f *File, x ast.Expr, varied map[types.Object]bool) bool {
+	same := true;
+	ast.Inspect(x, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if !bytesConversion(f, n) {
+				same = false;
+			}
+		case *ast.Ident:
+			obj := f.pkg.info.ObjectOf(n);
+			if obj == nil || varied[obj] {
+				same = false;
+			}
+			if v, ok := obj.(*types.Var); ok && v.Parent() == v.Pkg().Scope() {
+				// package level variables may be changed by calls in the loop
+				same = false;
+			}
+		}
+		return same;
+	});
+	return same;
+}
+
+// freeConversions returns conversions the compiler does without copying,
+// in comparisons, as map keys and as a range expression
+func freeConversions(f *File, loop ast.Node) map[ast.Expr]bool {
+	free := make(map[ast.Expr]bool);
+	ast.Inspect(loop, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.BinaryExpr:
+			switch n.Op {
+			case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ, token.ADD:
+				free[n.X] = true;
+				free[n.Y] = true;
+			}
+		case *ast.IndexExpr:
+			if t := f.pkg.info.TypeOf(n.X); t != nil {
+				if _, ok := t.Underlying().(*types.Map); ok {
+					free[n.Index] = true;
+				}
+			}
+		case *ast.RangeStmt:
+			free[n.X] = true;
+		}
+		return true;
+	});
+	return free;
+}
+
+func loopConvertCheck(f *File, node ast.Node) {
+	var body *ast.BlockStmt;
+	switch loop := node.(type) {
+	case *ast.ForStmt:
+		body = loop.Body;
+	case *ast.RangeStmt:
+		body = loop.Body;
+	default:
+		return;
+	}
+	varied := loopVaried(f, node);
+	free := freeConversions(f, body);
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt, *ast.FuncLit:
+			// nested loops are checked on their own
+			return false;
+		case *ast.CallExpr:
+			if !bytesConversion(f, n) || free[n] {
+				return true;
+			}
+			if invariant(f, n.Args[0], varied) {
+				f.Reportf(n.Pos(), "%s converts the same value on every loop iteration, convert it once before the loop", f.ASTString(n));
+				return false;
+			}
+		}
+		return true;
+	});
+	return;
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("lostAppend",
+		"this tests for append called as a statement with its result dropped",
+		severityMedium,
+		categoryCorrectness,
+		lostAppendCheck,
+		exprStmt)
+}
+
+func lostAppendCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.ExprStmt);
+	if !ok {
+		return;
+	}
+	call, ok := ast.Unparen(stmt.X).(*ast.CallExpr);
+	if !ok || !isBuiltin(f, call, "append") {
+		return;
+	}
+	f.Reportf(call.Pos(), "append result dropped, the appended values are lost: %s", f.ASTString(call));
+	return;
+}
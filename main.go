@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"flag"
 	"go/ast"
@@ -14,14 +15,28 @@ import (
 	"strings"
 	"os"
 	"path/filepath"
+	"regexp"
+	"time"
 )
 
 var stdImporter types.Importer
 
 var (
 	source = flag.Bool("source", false, "import from source instead of compiled object files")
+	pathPrefixTrim = flag.String("path-prefix-trim", "", "prefix to strip from reported file paths")
+	pathPrefixAdd = flag.String("path-prefix-add", "", "prefix to prepend to reported file paths")
+	paths = flag.String("paths", "relative", "how to report file paths: relative or absolute")
+	outputFormat = flag.String("fmt", "text", "output format: text, csv, junit or ndjson")
+	groupBy = flag.String("group-by", "file", "how to group text output: file, checker or severity")
+	buildTags = flag.String("tags", "", "comma separated build tags to consider satisfied when picking files in a directory")
+	excludeGenerated = flag.Bool("exclude-generated", false, "skip files marked // Code generated ... DO NOT EDIT.")
+	maxDepth = flag.Int("max-depth", -1, "how many directories below each root to analyze, 0 is just the root, -1 is no limit")
+	checkerTimeout = flag.Duration("checker-timeout", 0, "abandon a checker that runs longer than this on a node for the rest of the package, 0 means no timeout")
 )
 
+// workDir is the directory relative paths are reported against
+var workDir string
+
 // a global variable for the exit code.
 var exitCode = 0;
 
@@ -32,6 +47,7 @@ var (
 	// These are the relevant AST node types to check
 	// with corresponding cases
 	assignStmt	*ast.AssignStmt
+	basicLit	*ast.BasicLit
 	binaryExpr	*ast.BinaryExpr
 	callExpr	*ast.CallExpr
 	compositeLit	*ast.CompositeLit
@@ -41,20 +57,55 @@ var (
 	funcDecl	*ast.FuncDecl
 	funcLit		*ast.FuncLit
 	genDecl		*ast.GenDecl
+	goStmt		*ast.GoStmt
+	ifStmt		*ast.IfStmt
+	indexExpr	*ast.IndexExpr
 	interfaceType	*ast.InterfaceType
 	rangeStmt	*ast.RangeStmt
 	returnStmt	*ast.ReturnStmt
+	selectStmt	*ast.SelectStmt
 	structType	*ast.StructType
+	switchStmt	*ast.SwitchStmt
+	typeAssertExpr	*ast.TypeAssertExpr
+	typeSwitchStmt	*ast.TypeSwitchStmt
+)
+
+// severities for findings
+const (
+	severityLow	= "low"
+	severityMedium	= "medium"
+	severityHigh	= "high"
 )
 
+// categories of checkers, used to pick checkers by -profile
+const (
+	categorySecurity	= "security"
+	categoryCorrectness	= "correctness"
+	categoryStyle		= "style"
+	categoryPerformance	= "performance"
+)
+
+// checker is a registered test
+type checker struct {
+	name		string
+	usage		string
+	severity	string
+	category	string
+	fn		func(*File, ast.Node)
+	// optIn is set for checkers off by default, which only run with -include
+	// or when optIn reports that the checker's own flag turns it on
+	optIn		func() bool
+}
+
 var (
 	// checkers is a map to a map
-	// the map maps AST types to maps of checker names to checker functions
+	// the map maps AST types to maps of checker names to checkers
 	// this is to first get the functions needed for a certain type
 	// and second to take just the functions we want to run.
-	// refactor this to map to struct
-	checkers	= make(map[ast.Node]map[string]func(*File, ast.Node))
-	
+	checkers	= make(map[ast.Node]map[string]*checker)
+
+	// registered holds every checker by name
+	registered	= make(map[string]*checker)
 )
 
 // A map 
@@ -72,13 +123,67 @@ type File struct {
 	b	bytes.Buffer // used for logging and printing results
 
 	// a map of all registered checkers to run for each node
-	checkers map[ast.Node][]func(*File, ast.Node);
+	checkers map[ast.Node][]*checker;
+
+	// the checker currently running, findings are reported under its name
+	checker	*checker
+
+	// abandoned is set, holding findingsMu, once the checker runs past
+	// -checker-timeout so anything it reports later is dropped
+	abandoned	bool
+
+	// stack holds the nodes enclosing the node currently being visited
+	// so checkers can look at their surroundings, e.g. the enclosing function
+	stack	[]ast.Node
+
+	// nodeCounts counts the nodes of each checked type for -debug-nodes
+	nodeCounts	map[ast.Node]int
 }
 
 // Reportf reports issues to a log for each file for later printing
 func (f *File) Reportf(pos token.Pos, format string, args ...interface{}) {
-	// update this to use a logger
-	fmt.Fprintf(os.Stderr, "\t* %v %s \n", f.loc(pos), fmt.Sprintf(format, args...));
+	f.ReportFix(pos, nil, format, args...);
+}
+
+// suppressed checks if a finding at pos would be dropped by ReportFix,
+// outside the -changed-from lines or silenced by //glasgo:ignore
+func (f *File) suppressed(pos token.Pos) bool {
+	posn := f.fset.Position(pos);
+	return !changed(posn.Filename, posn.Line) || f.ignored(posn.Line, f.checker.name);
+}
+
+// ReportFix reports an issue along with the edits that fix it, applied with -fix
+func (f *File) ReportFix(pos token.Pos, fix []Edit, format string, args ...interface{}) {
+	if f.suppressed(pos) {
+		return;
+	}
+	posn := f.fset.Position(pos);
+	finding := Finding{
+		Checker:	f.checker.name,
+		Severity:	f.checker.severity,
+		Message:	fmt.Sprintf(format, args...),
+		Fix:		fix,
+	}
+	// the snippet is read from the real path before it is rewritten for display
+	if *snippet {
+		finding.Snippet = snippetFor(posn.Filename, posn.Line, posn.Column);
+	}
+	finding.Path = posn.Filename;
+	if abs, err := filepath.Abs(posn.Filename); err == nil {
+		finding.Path = abs;
+	}
+	posn.Filename = displayPath(posn.Filename);
+	finding.Pos = posn;
+	// checkers that timed out may still be reporting from their own goroutine
+	findingsMu.Lock();
+	defer findingsMu.Unlock();
+	if f.abandoned {
+		return;
+	}
+	findings = append(findings, finding);
+	if streaming() {
+		streamFinding(finding);
+	}
 }
 
 // loc (line of code) returns a formatted string of file and a file position
@@ -88,7 +193,28 @@ func (f *File) loc(pos token.Pos) string {
 	}
 	// we won't print column, just line
 	posn := f.fset.Position(pos)
-	return fmt.Sprintf("%s:%d", posn.Filename, posn.Line);
+	return fmt.Sprintf("%s:%d", displayPath(posn.Filename), posn.Line);
+}
+
+// displayPath rewrites a file name for reporting
+// so paths from a build container match a developer's checkout
+func displayPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs;
+	}
+	// an absolute -path-prefix-trim is matched before the path is made relative
+	if *pathPrefixTrim != "" && strings.HasPrefix(name, *pathPrefixTrim) {
+		return *pathPrefixAdd + strings.TrimPrefix(name, *pathPrefixTrim);
+	}
+	if *paths == "relative" && workDir != "" && filepath.IsAbs(name) {
+		if rel, err := filepath.Rel(workDir, name); err == nil {
+			name = rel;
+		}
+	}
+	if *pathPrefixTrim != "" {
+		name = strings.TrimPrefix(name, *pathPrefixTrim);
+	}
+	return *pathPrefixAdd + name;
 }
 
 // warnf is a formatted error printer that does not exit
@@ -98,27 +224,60 @@ func warnf(format string, args ...interface{}) {
 	exitCode = 1;
 }
 
+// inList checks if name is in a comma separated list such as a flag value
+func inList(list, name string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.TrimSpace(item) == name {
+			return true;
+		}
+	}
+	return false;
+}
+
 // register registers the named checker function
 // to be called with AST nodes of the given types.
-func register(name, usage string, fn func(*File, ast.Node), types ...ast.Node) {
+func register(name, usage, severity, category string, fn func(*File, ast.Node), types ...ast.Node) {
 	report[name] = true;
+	c := &checker{
+		name:		name,
+		usage:		usage,
+		severity:	severity,
+		category:	category,
+		fn:		fn,
+	}
+	registered[name] = c;
 	for _, typ := range types {
 		m, ok := checkers[typ];
 		if !ok {
-			m = make(map[string]func(*File, ast.Node));
+			m = make(map[string]*checker);
 			checkers[typ] = m;
 		}
-		m[name] = fn;
+		m[name] = c;
 	}
 }
 
+// registerOptIn registers a checker that is off by default,
+// run with -include or when on reports that its own flag is set
+func registerOptIn(name, usage, severity, category string, on func() bool, fn func(*File, ast.Node), types ...ast.Node) {
+	register(name, usage, severity, category, fn, types...);
+	registered[name].optIn = on;
+	report[name] = false;
+}
+
 // Visit implements the visitor interface we need to walk the tree
 // ast.Walk calls v.Visit(node)
 func (f *File) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		// ast.Walk calls Visit(nil) when it is done with a node's children
+		f.stack = f.stack[:len(f.stack)-1];
+		return nil;
+	}
 	var key ast.Node
 	switch node.(type) {
 	case *ast.AssignStmt:
 		key = assignStmt
+	case *ast.BasicLit:
+		key = basicLit
 	case *ast.BinaryExpr:
 		key = binaryExpr
 	case *ast.CallExpr:
@@ -137,27 +296,179 @@ func (f *File) Visit(node ast.Node) ast.Visitor {
 		key = funcLit
 	case *ast.GenDecl:
 		key = genDecl
+	case *ast.GoStmt:
+		key = goStmt
+	case *ast.IfStmt:
+		key = ifStmt
+	case *ast.IndexExpr:
+		key = indexExpr
 	case *ast.InterfaceType:
 		key = interfaceType
 	case *ast.RangeStmt:
 		key = rangeStmt
 	case *ast.ReturnStmt:
 		key = returnStmt
+	case *ast.SelectStmt:
+		key = selectStmt
 	case *ast.StructType:
 		key = structType
+	case *ast.SwitchStmt:
+		key = switchStmt
+	case *ast.TypeAssertExpr:
+		key = typeAssertExpr
+	case *ast.TypeSwitchStmt:
+		key = typeSwitchStmt
 	}
-	// runs checkers below
-	for _, fn := range f.checkers[key] {
-		fn(f, node)
+	// runs checkers below, or just counts the node with -debug-nodes
+	if *debugNodes {
+		if key != nil {
+			f.countNode(key);
+		}
+	} else {
+		for _, c := range f.checkers[key] {
+			f.run(c, node);
+		}
 	}
+	f.stack = append(f.stack, node);
 	return f;
 }
 
+// run calls a checker on a node
+// with -checker-timeout set a slow checker is abandoned so the run can go on
+func (f *File) run(c *checker, node ast.Node) {
+	if *showStats {
+		start := time.Now();
+		defer func() {
+			recordStat(c.name, time.Since(start));
+		}()
+	}
+	if *checkerTimeout <= 0 {
+		f.checker = c;
+		c.fn(f, node);
+		return;
+	}
+	if f.pkg.timedOut[c] {
+		return;
+	}
+	// the checker gets its own copy of the file state
+	// since the walk carries on without it if it times out,
+	// an abandoned checker is never waited for, it is left to finish or leak
+	fc := &File{
+		pkg:		f.pkg,
+		fset:		f.fset,
+		name:		f.name,
+		file:		f.file,
+		checker:	c,
+		stack:		append([]ast.Node(nil), f.stack...),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *checkerTimeout);
+	defer cancel();
+	done := make(chan struct{});
+	go func() {
+		c.fn(fc, node);
+		close(done);
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		warnf("checker %s timed out after %s on %s, skipping it for the rest of the package", c.name, *checkerTimeout, f.name);
+		if f.pkg.timedOut == nil {
+			f.pkg.timedOut = make(map[*checker]bool);
+		}
+		f.pkg.timedOut[c] = true;
+		findingsMu.Lock();
+		fc.abandoned = true;
+		findingsMu.Unlock();
+	}
+}
+
+// enclosingFunc returns the innermost *ast.FuncDecl or *ast.FuncLit
+// containing the node being visited or nil if there is none.
+func (f *File) enclosingFunc() ast.Node {
+	for i := len(f.stack) - 1; i >= 0; i-- {
+		switch fun := f.stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return fun;
+		}
+	}
+	return nil;
+}
+
+// parent returns the node directly enclosing the node being visited
+func (f *File) parent() ast.Node {
+	if len(f.stack) == 0 {
+		return nil;
+	}
+	return f.stack[len(f.stack)-1];
+}
+
+// funcLitStmt returns the go or defer statement calling the innermost
+// function literal containing the node being visited, i.e. go func() {...}()
+// it returns nil if the function literal is not called that way
+func (f *File) funcLitStmt() ast.Stmt {
+	for i := len(f.stack) - 1; i >= 2; i-- {
+		switch f.stack[i].(type) {
+		case *ast.FuncDecl:
+			return nil;
+		case *ast.FuncLit:
+			call, ok := f.stack[i-1].(*ast.CallExpr);
+			if !ok || call.Fun != f.stack[i] {
+				return nil;
+			}
+			switch stmt := f.stack[i-2].(type) {
+			case *ast.GoStmt:
+				return stmt;
+			case *ast.DeferStmt:
+				return stmt;
+			}
+			return nil;
+		}
+	}
+	return nil;
+}
+
+// enclosingLoop returns the innermost *ast.ForStmt or *ast.RangeStmt
+// containing the node being visited within the same function or nil
+func (f *File) enclosingLoop() ast.Node {
+	for i := len(f.stack) - 1; i >= 0; i-- {
+		switch n := f.stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return n;
+		case *ast.FuncDecl, *ast.FuncLit:
+			return nil;
+		}
+	}
+	return nil;
+}
+
+// enclosingFuncDecl returns the top level function declaration
+// containing the node being visited or nil if there is none.
+func (f *File) enclosingFuncDecl() *ast.FuncDecl {
+	for _, node := range f.stack {
+		if fun, ok := node.(*ast.FuncDecl); ok {
+			return fun;
+		}
+	}
+	return nil;
+}
+
 type Package struct {
 	path	string
 	types 	map[ast.Expr]types.TypeAndValue;
 	typePkg	*types.Package
 	info	*types.Info
+
+	// lockOrders records where each pair of mutexes was locked in order
+	// across the whole package for lockOrder
+	lockOrders	map[lockPair]token.Pos
+	// muxPatterns records the patterns registered on each mux for muxPattern
+	muxPatterns	map[types.Object][]muxPattern
+	// onceCalls records the first Do call on each sync.Once for onceDo
+	onceCalls	map[types.Object]onceCall
+
+	// checkers abandoned after running past -checker-timeout,
+	// they are skipped for the rest of the package
+	timedOut	map[*checker]bool
 }
 
 func (pkg *Package) check(fs *token.FileSet, astFiles []*ast.File) error {
@@ -174,12 +485,15 @@ func (pkg *Package) check(fs *token.FileSet, astFiles []*ast.File) error {
 		Importer: stdImporter,
 		Error: func(err error) { 
 				// todo refactor this
-				fmt.Printf("\tWarning: during type checking, %v\n", err)
+				fmt.Fprintf(os.Stderr, "\tWarning: during type checking, %v\n", err)
 			},
 	}
 
 	info := types.Info{
 		Types: pkg.types,
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
 	}
 
 	// Type-Check the package.
@@ -194,14 +508,13 @@ func (pkg *Package) check(fs *token.FileSet, astFiles []*ast.File) error {
 // checkPackage for analysis
 func checkPackageDir(directory string) {
 	context := build.Default
-	// gets build tags if any exist in order to preserve them through the coming import
-	/*
-	these are commented out until proof is made of being necessary
-	if len(context.BuildTags) != 0 {
-		warnf("build tags already set: %s," context.BuildTags);
+	// -tags are added to any already set so files behind them are included
+	// and only the files matching them are parsed and type checked
+	for _, tag := range strings.Split(*buildTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			context.BuildTags = append(context.BuildTags, tag);
+		}
 	}
-	context.BuildTags = append(tagList, context.BuildTags...);
-	*/
 
 	pkg, err := context.ImportDir(directory, 0); // 0 means no ImportMode is set i.e. default
 	if err != nil {
@@ -219,13 +532,10 @@ func checkPackageDir(directory string) {
 	names = append(names, pkg.TestGoFiles...);
 	/* there are other types include binary files that can be added */
 	
-	/* prefix each file with the directory name
-	 * could use a refactor
-	*/
-	if directory != "." {
-		for i, name := range names{
-			names[i] = filepath.Join(directory, name);
-		}
+	// prefix each file with the directory name
+	// how the path is reported is up to displayPath
+	for i, name := range names{
+		names[i] = filepath.Join(directory, name);
 	}
 	checkPackage(names);
 }
@@ -238,6 +548,34 @@ func checkPackage(names []string) {
 	var astFiles []*ast.File;
 	fset := token.NewFileSet();
 	var err error;
+	var key string;
+	// -debug-nodes has to walk the files, which a cached package skips
+	if *cacheDir != "" && !*debugNodes {
+		if key, err = cacheKey(names); err != nil {
+			warnf("cannot hash package for the cache: %s", err);
+			key = "";
+		} else if cached, ok := loadCache(key); ok {
+			for _, name := range names {
+				if !strings.HasSuffix(name, ".go") {
+					continue;
+				}
+				if *outputFormat == "text" {
+					fmt.Printf("Checking %s\n", displayPath(name));
+				} else if streaming() {
+					streamFile(displayPath(name));
+				}
+			}
+			findingsMu.Lock();
+			findings = append(findings, cached...);
+			if streaming() {
+				for _, finding := range cached {
+					streamFinding(finding);
+				}
+			}
+			findingsMu.Unlock();
+			return;
+		}
+	}
 	for _, name := range names {
 		// skipping using ioutil to read the file data
 		// and just going to parse files directly.
@@ -278,29 +616,123 @@ func checkPackage(names []string) {
 		file.pkg = pkg;
 	}
 
-	chk := make(map[ast.Node][]func(*File, ast.Node));
+	chk := make(map[ast.Node][]*checker);
 	for typ, set := range checkers {
-		for name, fn := range set {
+		for name, c := range set {
 			// check to see if named function will be run and reported
-			_, ok := report[name];
-			if ok {
-				chk[typ] = append(chk[typ], fn);
+			if report[name] {
+				chk[typ] = append(chk[typ], c);
 			}
 		}
 	}
+	findingsMu.Lock();
+	start := len(findings);
+	findingsMu.Unlock();
 	for _, file := range files {
 		file.checkers = chk
+		// generated files are still type checked with the package, just not reported on
+		if *excludeGenerated && file.file != nil && isGenerated(file.file) {
+			continue;
+		}
 		if file.file != nil {
 			// Should this go in to a new function to make it more readable?
 			// file.walkFile(file.name, file.file) as a method?
-			fmt.Printf("Checking %s\n", file.name);
+			if *outputFormat == "text" {
+				fmt.Printf("Checking %s\n", displayPath(file.name));
+			} else if streaming() {
+				streamFile(displayPath(file.name));
+			}
 			ast.Walk(file, file.file);
+			if *debugNodes {
+				if err := writeNodeCounts(os.Stdout, displayPath(file.name), file.nodeCounts); err != nil {
+					warnf("error writing node counts: %s", err);
+				}
+			}
+		}
+	}
+	// type check errors are warned about on every run, so are not cached
+	if key == "" || err != nil {
+		return;
+	}
+	// findings from a checker that timed out may be incomplete, so are not cached
+	if len(pkg.timedOut) != 0 {
+		return;
+	}
+	findingsMu.Lock();
+	pkgFindings := append([]Finding(nil), findings[start:]...);
+	findingsMu.Unlock();
+	storeCache(key, pkgFindings);
+}
+
+// generatedMarker is the Go convention for marking generated files
+var generatedMarker = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated checks the comments before the package clause for the generated marker
+func isGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break;
+		}
+		for _, comment := range group.List {
+			if generatedMarker.MatchString(comment.Text) {
+				return true;
+			}
+		}
+	}
+	return false;
+}
+
+// analyzed holds the absolute paths of directories and files already checked this run
+var analyzed = make(map[string]bool)
+
+// firstVisit marks a directory or file analyzed and checks if it was not already
+// overlapping roots and repeated arguments reach the same path under different names
+func firstVisit(path string) bool {
+	abs, err := filepath.Abs(path);
+	if err != nil {
+		abs = path;
+	}
+	if analyzed[abs] {
+		return false;
+	}
+	analyzed[abs] = true;
+	return true;
+}
+
+// uniqueFiles drops files named more than once from the command line arguments
+func uniqueFiles(names []string) []string {
+	var unique []string;
+	for _, name := range names {
+		if firstVisit(name) {
+			unique = append(unique, name);
 		}
 	}
+	return unique;
+}
+
+// tooDeep checks if a directory is more than -max-depth below root
+// depth 0 is root itself
+func tooDeep(root, path string) bool {
+	if *maxDepth < 0 {
+		return false;
+	}
+	rel, err := filepath.Rel(root, path);
+	if err != nil || rel == "." {
+		return false;
+	}
+	depth := len(strings.Split(rel, string(filepath.Separator)));
+	return depth > *maxDepth;
+}
+
+// visitRoot returns the function for walking an input directory root
+func visitRoot(root string) filepath.WalkFunc {
+	return func(path string, info os.FileInfo, err error) error {
+		return visit(root, path, info, err);
+	}
 }
 
 // visit is for walking input directory roots
-func visit(path string, info os.FileInfo, err error) error {
+func visit(root, path string, info os.FileInfo, err error) error {
 	if err != nil {
 		warnf("directory walk error: %s", err);
 		return err;
@@ -309,7 +741,13 @@ func visit(path string, info os.FileInfo, err error) error {
 	if !info.IsDir() {
 		return nil
 	}
-	checkPackageDir(path);
+	if tooDeep(root, path) {
+		return filepath.SkipDir;
+	}
+	// overlapping roots such as a directory and its parent reach the same package twice
+	if firstVisit(path) {
+		checkPackageDir(path);
+	}
 	return nil;
 }
 
@@ -362,10 +800,142 @@ func getFullFuncName(node ast.Node) (string, error) {
 	return "", fmt.Errorf("type conversion of CallExpr failed, no name extracted, %v", node);
 }
 
+// importPath returns the import path of the package named by x
+// in a selector such as os.Exit or "" if x is not a package name.
+func (f *File) importPath(x ast.Expr) string {
+	id, ok := x.(*ast.Ident);
+	if !ok {
+		return "";
+	}
+	if obj := f.pkg.info.Uses[id]; obj != nil {
+		if pkgName, ok := obj.(*types.PkgName); ok {
+			return pkgName.Imported().Path();
+		}
+		return "";
+	}
+	// no type information, fall back on the file's imports
+	for _, imp := range f.file.Imports {
+		path := strings.Trim(imp.Path.Value, "\"");
+		name := filepath.Base(path);
+		if imp.Name != nil {
+			name = imp.Name.Name;
+		}
+		if name == id.Name {
+			return path;
+		}
+	}
+	return "";
+}
+
+// getMethod returns the full name of a called method
+// i.e. "(*sync.WaitGroup).Add" for wg.Add(1)
+func (f *File) getMethod(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return "";
+	}
+	selection, ok := f.pkg.info.Selections[sel];
+	if !ok || selection.Kind() != types.MethodVal {
+		return "";
+	}
+	return selection.Obj().(*types.Func).FullName();
+}
+
+// getPkgFunc returns the import path and name of a called package level
+// function, i.e. "os" and "Exit" for os.Exit(1).
+func (f *File) getPkgFunc(call *ast.CallExpr) (string, string) {
+	if fun, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if path := f.importPath(fun.X); path != "" {
+			return path, fun.Sel.Name;
+		}
+	}
+	return "", "";
+}
+
 func main() {
 	var runOnDirs, runOnFiles bool;
 	flag.Parse();
 
+	writeFindings, ok := formatters[*outputFormat];
+	if !ok {
+		fmt.Printf("error: unknown output format %s\n", *outputFormat);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if *groupBy != "file" && *groupBy != "checker" && *groupBy != "severity" {
+		fmt.Printf("error: -group-by must be file, checker or severity, not %s\n", *groupBy);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+
+	if *paths != "relative" && *paths != "absolute" {
+		fmt.Printf("error: -paths must be relative or absolute, not %s\n", *paths);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if *rulesDir != "" {
+		loadRules(*rulesDir);
+	}
+	if *rulesFile != "" {
+		if err := loadRulesFile(*rulesFile); err != nil {
+			fmt.Printf("error: %s\n", err);
+			exitCode = 1;
+			os.Exit(exitCode);
+		}
+	}
+	if *listProfiles {
+		printProfiles();
+		os.Exit(exitCode);
+	}
+	if err := applyProfile(); err != nil {
+		fmt.Printf("error: %s\n", err);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if err := applySeverityOverrides(); err != nil {
+		fmt.Printf("error: %s\n", err);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if err := checkQuietCheckers(); err != nil {
+		fmt.Printf("error: %s\n", err);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if err := loadSecretRegex(); err != nil {
+		fmt.Printf("error: %s\n", err);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if err := loadChangedLines(); err != nil {
+		fmt.Printf("error: %s\n", err);
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if *baselineUpdate && *baselineFile == "" {
+		fmt.Println("error: -baseline-update needs -baseline to name the file");
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if *baselineAcceptNew && !*baselineUpdate {
+		fmt.Println("error: -baseline-accept-new only works with -baseline-update");
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if *annotateWrite {
+		*annotate = true;
+	}
+	if *annotate && (*fixFindings || *watchMode) {
+		fmt.Println("error: -annotate cannot be used with -fix or -watch");
+		exitCode = 1;
+		os.Exit(exitCode);
+	}
+	if wd, err := os.Getwd(); err == nil {
+		workDir = wd;
+	} else {
+		warnf("cannot get working directory, reporting absolute paths: %s", err);
+	}
+
 	for _, name := range flag.Args() {
 		// check to see if cl argument is a directory
 		f, err := os.Stat(name);
@@ -385,18 +955,52 @@ func main() {
 		exitCode = 1;
 		os.Exit(exitCode);
 	}
+	if *watchMode {
+		watch(flag.Args(), runOnDirs, writeFindings);
+		os.Exit(exitCode);
+	}
 	if runOnDirs {
 		// I want to do each directory in order
 		// so I am going to loop through these regardless
 		// root is a name of a directory, at the root, to be walked
 		for _, root := range flag.Args() {
-			filepath.Walk(root, visit);
+			filepath.Walk(root, visitRoot(root));
+		}
+	} else {
+		// else they are just file names
+		checkPackage(uniqueFiles(flag.Args()));
+	}
+	sortFindings(findings);
+	findings = preferSpecific(dedupeFindings(findings));
+	if baselined, err := applyBaseline(findings); err != nil {
+		warnf("error reading baseline %s: %s", *baselineFile, err);
+		exitCode = 1;
+	} else {
+		findings = baselined;
+	}
+	if *fixFindings {
+		findings = applyFixes(findings);
+	}
+	// findings fail the run unless their checker is quiet
+	if failing(findings) {
+		exitCode = 1;
+	}
+	// text findings have always gone to stderr
+	out := os.Stdout;
+	if *outputFormat == "text" {
+		out = os.Stderr;
+	}
+	if *annotate {
+		annotateFindings(os.Stdout, findings);
+	} else if err := writeFindings(out, findings); err != nil {
+		warnf("error writing findings: %s", err);
+	}
+	// stats go to stderr so they never mix with csv or junit on stdout
+	if *showStats {
+		if err := writeStats(os.Stderr, findings); err != nil {
+			warnf("error writing stats: %s", err);
 		}
-		os.Exit(exitCode);
 	}
-	// else they are just file names
-	fileNames := flag.Args();	
-	checkPackage(fileNames);
-	return;
+	os.Exit(exitCode);
 }
 
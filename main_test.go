@@ -0,0 +1,353 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setFlag sets a command line flag for the rest of a test
+func setFlag(t *testing.T, name, value string) {
+	t.Helper();
+	old := flag.Lookup(name).Value.String();
+	if err := flag.Set(name, value); err != nil {
+		t.Fatal(err);
+	}
+	t.Cleanup(func() {
+		flag.Set(name, old);
+	});
+}
+
+// unregister removes a checker registered by a test
+func unregister(name string) {
+	delete(registered, name);
+	delete(report, name);
+	for _, set := range checkers {
+		delete(set, name);
+	}
+}
+
+// saveReport puts back which checkers run and drops the findings at the end of a test
+func saveReport(t *testing.T) {
+	saved := make(map[string]bool);
+	for name, on := range report {
+		saved[name] = on;
+	}
+	t.Cleanup(func() {
+		for name, on := range saved {
+			report[name] = on;
+		}
+		findings = nil;
+	});
+}
+
+// checkFiles runs the enabled checkers on the files of a package
+// and returns what they found
+func checkFiles(t *testing.T, files ...string) []Finding {
+	t.Helper();
+	// keeps the Checking lines off stdout
+	setFlag(t, "fmt", "csv");
+	findings = nil;
+	checkPackage(files);
+	return findings;
+}
+
+// runCheckers runs just the comma separated checkers on the files of a package
+func runCheckers(t *testing.T, names string, files ...string) []Finding {
+	t.Helper();
+	saveReport(t);
+	for name := range report {
+		report[name] = inList(names, name);
+	}
+	return checkFiles(t, files...);
+}
+
+func TestDisplayPath(t *testing.T) {
+	tests := []struct {
+		paths	string
+		trim	string
+		add	string
+		name	string
+		want	string
+	}{
+		{"relative", "", "", "/build/src/proj/pkg/a.go", "pkg/a.go"},
+		{"relative", "", "", "/elsewhere/a.go", "../../../elsewhere/a.go"},
+		{"absolute", "", "", "/build/src/proj/pkg/a.go", "/build/src/proj/pkg/a.go"},
+		// an absolute prefix is trimmed even though paths are relative
+		{"relative", "/build/src/", "", "/build/src/proj/pkg/a.go", "proj/pkg/a.go"},
+		{"absolute", "/build/src/", "", "/build/src/proj/pkg/a.go", "proj/pkg/a.go"},
+		{"relative", "/build/src/", "/home/dev/", "/build/src/proj/pkg/a.go", "/home/dev/proj/pkg/a.go"},
+		// a relative prefix is trimmed from the relative path
+		{"relative", "pkg/", "", "/build/src/proj/pkg/a.go", "a.go"},
+		{"relative", "pkg/", "lib/", "/build/src/proj/pkg/a.go", "lib/a.go"},
+		// a prefix that does not match leaves the path alone
+		{"relative", "/other/", "", "/build/src/proj/pkg/a.go", "pkg/a.go"},
+		{"absolute", "pkg/", "", "/build/src/proj/pkg/a.go", "/build/src/proj/pkg/a.go"},
+		{"relative", "", "./", "/build/src/proj/pkg/a.go", "./pkg/a.go"},
+	}
+	old := workDir;
+	workDir = "/build/src/proj";
+	defer func() {
+		workDir = old;
+	}();
+	for _, test := range tests {
+		setFlag(t, "paths", test.paths);
+		setFlag(t, "path-prefix-trim", test.trim);
+		setFlag(t, "path-prefix-add", test.add);
+		if got := displayPath(test.name); got != test.want {
+			t.Errorf("-paths=%s -path-prefix-trim=%q -path-prefix-add=%q: displayPath(%q) = %q, want %q", test.paths, test.trim, test.add, test.name, got, test.want);
+		}
+	}
+}
+
+func TestTooDeep(t *testing.T) {
+	tests := []struct {
+		maxDepth	string
+		path		string
+		want		bool
+	}{
+		{"-1", "root/a/b/c/d", false},
+		{"0", "root", false},
+		{"0", "root/a", true},
+		{"1", "root/a", false},
+		{"1", "root/a/b", true},
+		{"2", "root/a/b", false},
+		{"2", "root/a/b/c", true},
+		// a root given with a trailing slash or dot is the same root
+		{"1", "root/./a", false},
+	}
+	for _, test := range tests {
+		setFlag(t, "max-depth", test.maxDepth);
+		for _, root := range []string{"root", "root/", "./root"} {
+			if got := tooDeep(root, test.path); got != test.want {
+				t.Errorf("-max-depth=%s: tooDeep(%q, %q) = %v, want %v", test.maxDepth, root, test.path, got, test.want);
+			}
+		}
+	}
+}
+
+// TestMaxDepthWalk checks the walk stops analyzing directories below -max-depth
+func TestMaxDepthWalk(t *testing.T) {
+	root := t.TempDir();
+	for _, dir := range []string{"", "a", filepath.Join("a", "b")} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err);
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, "p.go"), []byte("package p\n"), 0644); err != nil {
+			t.Fatal(err);
+		}
+	}
+	saved := analyzed;
+	analyzed = make(map[string]bool);
+	defer func() {
+		analyzed = saved;
+	}();
+	saveReport(t);
+	setFlag(t, "fmt", "csv");
+	setFlag(t, "max-depth", "1");
+	filepath.Walk(root, visitRoot(root));
+	for dir, want := range map[string]bool{"": true, "a": true, filepath.Join("a", "b"): false} {
+		if got := analyzed[filepath.Join(root, dir)]; got != want {
+			t.Errorf("%q analyzed = %v, want %v", dir, got, want);
+		}
+	}
+}
+
+// TestBuildTags checks -tags picks which tag guarded files of a directory are checked
+func TestBuildTags(t *testing.T) {
+	dir := t.TempDir();
+	files := map[string]string{
+		"tagged.go":	"//go:build glasgotest\n\npackage p\n\nfunc tagged(x int) {\n\tx = x\n}\n",
+		"untagged.go":	"//go:build !glasgotest\n\npackage p\n\nfunc tagged(x int) {\n\tx = x\n}\n",
+		"always.go":	"package p\n\nfunc always(x int) {\n\tx = x\n}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err);
+		}
+	}
+	tests := []struct {
+		tags	string
+		want	[]string
+	}{
+		{"", []string{"always.go", "untagged.go"}},
+		{"glasgotest", []string{"always.go", "tagged.go"}},
+		{"other, glasgotest", []string{"always.go", "tagged.go"}},
+	}
+	for _, test := range tests {
+		setFlag(t, "tags", test.tags);
+		saveReport(t);
+		for name := range report {
+			report[name] = name == "selfAssign";
+		}
+		setFlag(t, "fmt", "csv");
+		findings = nil;
+		checkPackageDir(dir);
+		var got []string;
+		for _, finding := range findings {
+			got = append(got, filepath.Base(finding.Path));
+		}
+		sort.Strings(got);
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("-tags=%q: findings in %v, want %v", test.tags, got, test.want);
+		}
+	}
+}
+
+// TestOverlappingRoots checks a package reached from two roots is analyzed once
+func TestOverlappingRoots(t *testing.T) {
+	root := t.TempDir();
+	sub := filepath.Join(root, "sub");
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err);
+	}
+	if err := os.WriteFile(filepath.Join(sub, "sub.go"), []byte("package sub\n\nfunc sub(x int) {\n\tx = x\n}\n"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	saved := analyzed;
+	analyzed = make(map[string]bool);
+	defer func() {
+		analyzed = saved;
+	}();
+	saveReport(t);
+	for name := range report {
+		report[name] = name == "selfAssign";
+	}
+	setFlag(t, "fmt", "csv");
+	findings = nil;
+	// the child first, then its parent, and the child again by another name
+	for _, dir := range []string{sub, root, filepath.Join(root, ".", "sub")} {
+		filepath.Walk(dir, visitRoot(dir));
+	}
+	if len(findings) != 1 {
+		t.Errorf("got %d findings, want the package analyzed once: %v", len(findings), findings);
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		src	string
+		want	bool
+	}{
+		{"// Code generated by stringer; DO NOT EDIT.\n\npackage p\n", true},
+		{"// Copyright 2018\n\n// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: a.proto\n\npackage p\n", true},
+		{"//go:build linux\n\n// Code generated by go generate; DO NOT EDIT.\n\npackage p\n", true},
+		{"package p\n", false},
+		// the marker has to be a whole line comment before the package clause
+		{"package p\n\n// Code generated by stringer; DO NOT EDIT.\n", false},
+		{"// Code generated by stringer; DO NOT EDIT\n\npackage p\n", false},
+		{"// Code generated by stringer; do not edit.\n\npackage p\n", false},
+		{"/* Code generated by stringer; DO NOT EDIT. */\n\npackage p\n", false},
+		{"// This is not Code generated by anything; DO NOT EDIT.\n\npackage p\n", false},
+	}
+	for _, test := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "gen.go", test.src, parser.ParseComments);
+		if err != nil {
+			t.Fatal(err);
+		}
+		if got := isGenerated(file); got != test.want {
+			t.Errorf("isGenerated(%q) = %v, want %v", test.src, got, test.want);
+		}
+	}
+}
+
+// TestExcludeGenerated checks generated files are type checked but not reported on
+func TestExcludeGenerated(t *testing.T) {
+	dir := t.TempDir();
+	files := map[string]string{
+		"gen.go":	"// Code generated by hand; DO NOT EDIT.\n\npackage p\n\nfunc gen(x int) int {\n\tx = x\n\treturn x\n}\n",
+		"p.go":		"package p\n\nfunc p(x int) int {\n\tx = x\n\treturn gen(x)\n}\n",
+	}
+	var names []string;
+	for name, src := range files {
+		names = append(names, filepath.Join(dir, name));
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err);
+		}
+	}
+	for _, exclude := range []bool{false, true} {
+		setFlag(t, "exclude-generated", strconv.FormatBool(exclude));
+		var got []string;
+		for _, finding := range runCheckers(t, "selfAssign", names...) {
+			got = append(got, filepath.Base(finding.Path));
+		}
+		sort.Strings(got);
+		want := []string{"gen.go", "p.go"};
+		if exclude {
+			want = []string{"p.go"};
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("-exclude-generated=%v: findings in %v, want %v", exclude, got, want);
+		}
+	}
+}
+
+// TestCheckerTimeout checks the run finishes while a checker past -checker-timeout
+// never returns, the checker is skipped for the rest of the package
+// and what a timed out checker reports later is dropped
+func TestCheckerTimeout(t *testing.T) {
+	dir := t.TempDir();
+	var names []string;
+	for _, name := range []string{"a.go", "b.go"} {
+		src := "package p\n\nfunc " + name[:1] + "() {}\n";
+		names = append(names, filepath.Join(dir, name));
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err);
+		}
+	}
+	// the hung checker is only let go once the test is over
+	release := make(chan struct{});
+	t.Cleanup(func() {
+		close(release);
+	});
+	var hangCalls, lateCalls atomic.Int32;
+	registerTest(t, "hangDummy", func(f *File, node ast.Node) {
+		hangCalls.Add(1);
+		<-release;
+	}, funcDecl);
+	late := make(chan struct{});
+	registerTest(t, "lateDummy", func(f *File, node ast.Node) {
+		lateCalls.Add(1);
+		time.Sleep(50 * time.Millisecond);
+		f.Reportf(node.Pos(), "late");
+		close(late);
+	}, funcDecl);
+	registerTest(t, "quickDummy", func(f *File, node ast.Node) {
+		f.Reportf(node.Pos(), "quick");
+	}, funcDecl);
+	setFlag(t, "checker-timeout", "5ms");
+	// waiting on hangDummy would hang here until go test times out
+	found := runCheckers(t, "hangDummy,lateDummy,quickDummy", names...);
+	if hangCalls.Load() != 1 || lateCalls.Load() != 1 {
+		t.Errorf("timed out checkers called %d and %d times, want once each for the package", hangCalls.Load(), lateCalls.Load());
+	}
+	quick := 0;
+	for _, finding := range found {
+		if finding.Checker == "quickDummy" {
+			quick++;
+		}
+	}
+	if quick != 2 {
+		t.Errorf("got %d quickDummy findings, want one per file", quick);
+	}
+	<-late;
+	findingsMu.Lock();
+	defer findingsMu.Unlock();
+	for _, finding := range findings {
+		if finding.Checker == "lateDummy" {
+			t.Errorf("got %v reported after lateDummy timed out", finding);
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("makeIndex",
+		"this tests for constant indexes past the length of a slice made with make",
+		severityMedium,
+		categoryCorrectness,
+		makeIndexCheck,
+		indexExpr)
+}
+
+// madeLen returns the length a slice is made with if its only assignment
+// in fun is s := make([]T, n) with a constant n,
+// appends and other assignments change the length so s is skipped
+func madeLen(f *File, fun ast.Node, obj types.Object) (int64, bool) {
+	var made *ast.CallExpr;
+	assigned := 0;
+	ast.Inspect(fun, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if !refersTo(f, lhs, obj) {
+					continue;
+				}
+				assigned++;
+				if len(n.Rhs) == len(n.Lhs) {
+					made, _ = n.Rhs[i].(*ast.CallExpr);
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if f.pkg.info.ObjectOf(name) != obj || i >= len(n.Values) {
+					continue;
+				}
+				assigned++;
+				made, _ = n.Values[i].(*ast.CallExpr);
+			}
+		case *ast.UnaryExpr:
+			// &s can be used to change it
+			if refersTo(f, n.X, obj) {
+				assigned++;
+			}
+		}
+		return true;
+	});
+	if assigned != 1 || made == nil || !isBuiltin(f, made, "make") || len(made.Args) < 2 {
+		return 0, false;
+	}
+	if t := f.pkg.info.TypeOf(made.Args[0]); t == nil {
+		return 0, false;
+	} else if _, ok := t.Underlying().(*types.Slice); !ok {
+		return 0, false;
+	}
+	return constInt(f, made.Args[1]);
+}
+
+func makeIndexCheck(f *File, node ast.Node) {
+	index, ok := node.(*ast.IndexExpr);
+	if !ok {
+		return;
+	}
+	id, ok := index.X.(*ast.Ident);
+	if !ok {
+		return;
+	}
+	i, ok := constInt(f, index.Index);
+	if !ok {
+		return;
+	}
+	obj, ok := f.pkg.info.ObjectOf(id).(*types.Var);
+	if !ok || f.pkg.typePkg == nil || obj.Parent() == f.pkg.typePkg.Scope() {
+		return;
+	}
+	// closures can assign to variables of the function around them
+	var fun ast.Node = f.enclosingFuncDecl();
+	if fun == nil {
+		fun = f.enclosingFunc();
+	}
+	if fun == nil {
+		return;
+	}
+	length, ok := madeLen(f, fun, obj);
+	if !ok || i < length {
+		return;
+	}
+	f.Reportf(index.Pos(), "index %d is out of range for %s made with length %d, this panics, append to it or make it longer: %s", i, id.Name, length, f.ASTString(index));
+	return;
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("mapNil",
+		"this tests for using a map value that is nil when the key is missing",
+		severityMedium,
+		categoryCorrectness,
+		mapNilCheck,
+		indexExpr)
+}
+
+// nilable checks if the zero value of a type panics when used
+func nilable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Signature:
+		return true;
+	}
+	return false;
+}
+
+// lookupOks returns the ok variables of v, ok := m[k] lookups in fun
+// of the same map and key as index
+func lookupOks(f *File, fun ast.Node, index *ast.IndexExpr) map[types.Object]bool {
+	oks := make(map[types.Object]bool);
+	ast.Inspect(fun, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt);
+		if !ok || !commaOk(assign, assign.Rhs[0]) {
+			return true;
+		}
+		lookup, ok := assign.Rhs[0].(*ast.IndexExpr);
+		if !ok || f.ASTString(lookup.X) != f.ASTString(index.X) || f.ASTString(lookup.Index) != f.ASTString(index.Index) {
+			return true;
+		}
+		if id, ok := assign.Lhs[1].(*ast.Ident); ok && id.Name != "_" {
+			oks[f.pkg.info.ObjectOf(id)] = true;
+		}
+		return true;
+	});
+	return oks;
+}
+
+// checksOk checks if cond can only be true when one of oks is,
+// ok or ok && more, or with not set when one of oks is false, !ok or !ok || more
+func checksOk(f *File, cond ast.Expr, oks map[types.Object]bool, not bool) bool {
+	switch c := ast.Unparen(cond).(type) {
+	case *ast.Ident:
+		return !not && oks[f.pkg.info.ObjectOf(c)];
+	case *ast.UnaryExpr:
+		return not && c.Op == token.NOT && checksOk(f, c.X, oks, false);
+	case *ast.BinaryExpr:
+		if (!not && c.Op == token.LAND) || (not && c.Op == token.LOR) {
+			return checksOk(f, c.X, oks, not) || checksOk(f, c.Y, oks, not);
+		}
+	}
+	return false;
+}
+
+// leaves checks if a block always ends by returning, panicking or branching
+func leaves(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false;
+	}
+	switch last := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true;
+	case *ast.ExprStmt:
+		call, ok := last.X.(*ast.CallExpr);
+		if !ok {
+			return false;
+		}
+		id, ok := call.Fun.(*ast.Ident);
+		return ok && id.Name == "panic";
+	}
+	return false;
+}
+
+// guarded checks if a comma-ok lookup of the same map and key comes first,
+// if _, ok := m[k]; ok { m[k].X } or if !ok { return } before m[k].X
+func guarded(f *File, index *ast.IndexExpr) bool {
+	fun := f.enclosingFunc();
+	if fun == nil {
+		return false;
+	}
+	oks := lookupOks(f, fun, index);
+	if len(oks) == 0 {
+		return false;
+	}
+	var inner ast.Node = index;
+	for i := len(f.stack) - 1; i >= 0 && f.stack[i] != fun; i-- {
+		switch n := f.stack[i].(type) {
+		case *ast.IfStmt:
+			if n.Body == inner && checksOk(f, n.Cond, oks, false) {
+				return true;
+			}
+		case *ast.BlockStmt:
+			// an earlier if !ok that leaves guards the rest of the block
+			for _, stmt := range n.List {
+				if stmt == inner {
+					break;
+				}
+				if s, ok := stmt.(*ast.IfStmt); ok && s.Else == nil && leaves(s.Body) && checksOk(f, s.Cond, oks, true) {
+					return true;
+				}
+			}
+		}
+		inner = f.stack[i];
+	}
+	return false;
+}
+
+func mapNilCheck(f *File, node ast.Node) {
+	index, ok := node.(*ast.IndexExpr);
+	if !ok {
+		return;
+	}
+	t := f.pkg.info.TypeOf(index.X);
+	if t == nil {
+		return;
+	}
+	m, ok := t.Underlying().(*types.Map);
+	if !ok || !nilable(m.Elem()) {
+		return;
+	}
+	// m[key].Field, m[key].Method() or m[key]()
+	used := false;
+	switch p := f.parent().(type) {
+	case *ast.SelectorExpr:
+		used = p.X == index;
+	case *ast.CallExpr:
+		used = p.Fun == index;
+	}
+	if used && !guarded(f, index) {
+		f.Reportf(index.Pos(), "%s is nil for a missing key, check with v, ok := %s first", f.ASTString(index), f.ASTString(index));
+	}
+	return;
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+)
+
+var docComments = flag.Bool("doc-comments", false, "report exported declarations without a doc comment")
+
+func init() {
+	registerOptIn("missingDoc",
+		"this tests for exported functions, types, constants and variables without a doc comment",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *docComments;
+		},
+		missingDocCheck,
+		funcDecl, genDecl)
+}
+
+func missingDocCheck(f *File, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		if n.Doc != nil || !exportedFunc(f, n) {
+			return;
+		}
+		kind := "function";
+		if n.Recv != nil {
+			kind = "method";
+		}
+		f.Reportf(n.Name.Pos(), "exported %s %s has no doc comment", kind, n.Name.Name);
+	case *ast.GenDecl:
+		// a comment on the whole declaration documents every spec in it
+		if n.Tok == token.IMPORT || n.Doc != nil || f.enclosingFunc() != nil {
+			return;
+		}
+		for _, spec := range n.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if s.Doc == nil && s.Name.IsExported() {
+					f.Reportf(s.Name.Pos(), "exported type %s has no doc comment", s.Name.Name);
+				}
+			case *ast.ValueSpec:
+				if s.Doc != nil {
+					continue;
+				}
+				for _, name := range s.Names {
+					if name.IsExported() {
+						f.Reportf(name.Pos(), "exported %s %s has no doc comment", n.Tok, name.Name);
+						break;
+					}
+				}
+			}
+		}
+	}
+	return;
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+func init() {
+	register("muxPattern",
+		"this tests for http.ServeMux patterns that overlap in confusing ways",
+		severityMedium,
+		categorySecurity,
+		muxPatternCheck,
+		callExpr)
+}
+
+// muxPattern is a pattern registered on a mux and where it was registered
+type muxPattern struct {
+	pattern	string
+	pos	token.Pos
+}
+
+// muxPath drops the method and host from a pattern like "GET example.com/api/"
+func muxPath(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		pattern = strings.TrimSpace(pattern[i+1:]);
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:];
+	}
+	return pattern;
+}
+
+// muxConflict describes how two patterns on the same mux are confusing
+// or returns "" if they are not
+func muxConflict(a, b string) string {
+	pa, pb := muxPath(a), muxPath(b);
+	switch {
+	case a == b:
+		return "are the same, registering both panics";
+	case pa != pb && strings.TrimSuffix(pa, "/") == strings.TrimSuffix(pb, "/"):
+		return "differ only by a trailing slash, routing the same path to different handlers";
+	case !strings.HasSuffix(pa, "/") && strings.HasPrefix(pb, pa+"/"):
+		return fmt.Sprintf("overlap but %q does not end in / so it only matches exactly", a);
+	case !strings.HasSuffix(pb, "/") && strings.HasPrefix(pa, pb+"/"):
+		return fmt.Sprintf("overlap but %q does not end in / so it only matches exactly", b);
+	}
+	return "";
+}
+
+func muxPatternCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) != 2 {
+		return;
+	}
+	var mux types.Object;
+	switch f.getMethod(call) {
+	case "(*net/http.ServeMux).Handle", "(*net/http.ServeMux).HandleFunc":
+		mux = mutexObj(f, call.Fun.(*ast.SelectorExpr).X);
+		if mux == nil {
+			return;
+		}
+	default:
+		path, name := f.getPkgFunc(call);
+		if path != "net/http" || (name != "Handle" && name != "HandleFunc") {
+			return;
+		}
+	}
+	pattern, ok := constString(f, call.Args[0]);
+	if !ok {
+		return;
+	}
+	// patterns are kept on the package so registrations in different files
+	// are compared, http.Handle and http.HandleFunc use the nil mux
+	if f.pkg.muxPatterns == nil {
+		f.pkg.muxPatterns = make(map[types.Object][]muxPattern);
+	}
+	byMux := f.pkg.muxPatterns;
+	for _, other := range byMux[mux] {
+		if conflict := muxConflict(pattern, other.pattern); conflict != "" {
+			f.Reportf(call.Args[0].Pos(), "patterns %q and %q registered at %s %s", pattern, other.pattern, f.loc(other.pos), conflict);
+			break;
+		}
+	}
+	byMux[mux] = append(byMux[mux], muxPattern{pattern, call.Args[0].Pos()});
+	return;
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"os"
+)
+
+// -fmt=ndjson writes one JSON object per line.
+// Files and findings are written as they are checked rather than
+// sorted at the end, so tools can show them while glasgo runs,
+// and a summary line ends the stream.
+type ndjsonFile struct {
+	Type	string	`json:"type"`
+	Name	string	`json:"name"`
+}
+
+type ndjsonFinding struct {
+	Type		string	`json:"type"`
+	File		string	`json:"file"`
+	Line		int	`json:"line"`
+	Col		int	`json:"col"`
+	Checker		string	`json:"checker"`
+	Severity	string	`json:"severity"`
+	Message		string	`json:"message"`
+	Snippet		string	`json:"snippet,omitempty"`
+}
+
+type ndjsonSummary struct {
+	Type		string		`json:"type"`
+	Files		int		`json:"files"`
+	Findings	int		`json:"findings"`
+	Severities	map[string]int	`json:"severities"`
+}
+
+// the stream goes to stdout like the other machine readable formats
+// streamed counts files and streamed findings since the last summary
+// so the same finding is only written once
+var (
+	ndjsonOut	io.Writer = os.Stdout
+	streamedFiles	int
+	streamed	= make(map[streamKey]bool)
+)
+
+type streamKey struct {
+	pos	token.Position
+	checker	string
+	message	string
+}
+
+// streaming checks if findings are written as they are reported
+func streaming() bool {
+	return *outputFormat == "ndjson";
+}
+
+// writeEvent writes one event as a line of JSON
+// the caller holds findingsMu so lines from timed out checkers don't interleave
+func writeEvent(event interface{}) {
+	line, err := json.Marshal(event);
+	if err != nil {
+		warnf("error encoding ndjson event: %s", err);
+		return;
+	}
+	if _, err := ndjsonOut.Write(append(line, '\n')); err != nil {
+		warnf("error writing ndjson event: %s", err);
+	}
+}
+
+// streamFile writes the event for a file about to be checked
+func streamFile(name string) {
+	findingsMu.Lock();
+	defer findingsMu.Unlock();
+	streamedFiles++;
+	writeEvent(ndjsonFile{Type: "file", Name: name});
+}
+
+// streamFinding writes the event for a finding, the caller holds findingsMu
+func streamFinding(finding Finding) {
+	key := streamKey{finding.Pos, finding.Checker, finding.Message};
+	if streamed[key] {
+		return;
+	}
+	streamed[key] = true;
+	writeEvent(ndjsonFinding{
+		Type:		"finding",
+		File:		finding.Pos.Filename,
+		Line:		finding.Pos.Line,
+		Col:		finding.Pos.Column,
+		Checker:	finding.Checker,
+		Severity:	finding.Severity,
+		Message:	finding.Message,
+		Snippet:	finding.Snippet,
+	});
+}
+
+// writeNDJSON ends the stream with a summary line, the findings
+// themselves were written as they were reported
+func writeNDJSON(w io.Writer, findings []Finding) error {
+	severities := make(map[string]int);
+	for _, finding := range findings {
+		severities[finding.Severity]++;
+	}
+	line, err := json.Marshal(ndjsonSummary{
+		Type:		"summary",
+		Files:		streamedFiles,
+		Findings:	len(findings),
+		Severities:	severities,
+	});
+	if err != nil {
+		return err;
+	}
+	// -watch streams again after each change
+	streamedFiles = 0;
+	streamed = make(map[streamKey]bool);
+	_, err = w.Write(append(line, '\n'));
+	return err;
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNDJSON checks every line of the stream is a JSON object,
+// files come before their findings and the summary comes last
+func TestNDJSON(t *testing.T) {
+	var b bytes.Buffer;
+	saved := ndjsonOut;
+	ndjsonOut = &b;
+	defer func() {
+		ndjsonOut = saved;
+	}();
+	saveReport(t);
+	for name := range report {
+		report[name] = name == "selfAssign" || name == "tautology";
+	}
+	setFlag(t, "fmt", "ndjson");
+	findings = nil;
+	checkPackage([]string{filepath.Join("testdata", "selfAssign.go"), filepath.Join("testdata", "tautology.go")});
+	if err := writeNDJSON(&b, findings); err != nil {
+		t.Fatal(err);
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n");
+	var types []string;
+	var summary ndjsonSummary;
+	files := make(map[string]bool);
+	streamedFindings := 0;
+	for i, line := range lines {
+		var event map[string]interface{};
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("line %d is not JSON: %s\n%s", i+1, err, line);
+		}
+		typ, _ := event["type"].(string);
+		types = append(types, typ);
+		switch typ {
+		case "file":
+			files[event["name"].(string)] = true;
+		case "finding":
+			streamedFindings++;
+			if file, _ := event["file"].(string); !files[file] {
+				t.Errorf("finding in %s before its file event", file);
+			}
+		case "summary":
+			if i != len(lines)-1 {
+				t.Errorf("summary on line %d of %d", i+1, len(lines));
+			}
+			if err := json.Unmarshal([]byte(line), &summary); err != nil {
+				t.Fatal(err);
+			}
+		default:
+			t.Errorf("line %d has unknown type %q", i+1, typ);
+		}
+	}
+	if streamedFindings == 0 {
+		t.Fatalf("no findings streamed: %v", types);
+	}
+	bySeverity := 0;
+	for _, n := range summary.Severities {
+		bySeverity += n;
+	}
+	if summary.Files != 2 || summary.Findings != streamedFindings || bySeverity != streamedFindings {
+		t.Errorf("got summary %+v for 2 files and %d findings", summary, streamedFindings);
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("nilError",
+		"this tests for functions whose error result is always nil",
+		severityLow,
+		categoryStyle,
+		nilErrorCheck,
+		funcDecl)
+}
+
+// errorResult returns the index of the error result of a function or -1
+func errorResult(f *File, ftype *ast.FuncType) int {
+	if ftype.Results == nil {
+		return -1;
+	}
+	i := 0;
+	for _, field := range ftype.Results.List {
+		n := len(field.Names);
+		if n == 0 {
+			n = 1;
+		}
+		if isError(f.pkg.info.TypeOf(field.Type)) {
+			return i;
+		}
+		i += n;
+	}
+	return -1;
+}
+
+// setsNamedError checks if a function assigns to a named error result,
+// or takes its address, anywhere in its body including function literals
+// such as a deferred recover setting it
+func setsNamedError(f *File, fun *ast.FuncDecl) bool {
+	named := make(map[types.Object]bool);
+	for _, field := range fun.Type.Results.List {
+		if !isError(f.pkg.info.TypeOf(field.Type)) {
+			continue;
+		}
+		for _, name := range field.Names {
+			if obj := f.pkg.info.Defs[name]; obj != nil {
+				named[obj] = true;
+			}
+		}
+	}
+	if len(named) == 0 {
+		return false;
+	}
+	set := false;
+	isNamed := func(x ast.Expr) bool {
+		id, ok := ast.Unparen(x).(*ast.Ident);
+		return ok && named[f.pkg.info.Uses[id]];
+	}
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				set = set || isNamed(lhs);
+			}
+		case *ast.UnaryExpr:
+			set = set || n.Op == token.AND && isNamed(n.X);
+		}
+		return !set;
+	})
+	return set;
+}
+
+func nilErrorCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	// methods often return a nil error to satisfy an interface
+	if !ok || fun.Body == nil || fun.Recv != nil {
+		return;
+	}
+	index := errorResult(f, fun.Type);
+	if index < 0 || setsNamedError(f, fun) {
+		return;
+	}
+	returns := 0;
+	alwaysNil := true;
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false;
+		case *ast.ReturnStmt:
+			returns++;
+			// a bare return or a call returning all results could be anything
+			if index >= len(n.Results) || !isNil(n.Results[index]) {
+				alwaysNil = false;
+			}
+		}
+		return alwaysNil;
+	})
+	if returns > 0 && alwaysNil {
+		f.Reportf(fun.Pos(), "%s always returns a nil error, the error result can be removed", fun.Name.Name);
+	}
+	return;
+}
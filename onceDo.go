@@ -0,0 +1,52 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("onceDo",
+		"this tests for a sync.Once whose Do is called with different functions",
+		severityMedium,
+		categoryCorrectness,
+		onceDoCheck,
+		callExpr)
+}
+
+// onceCall is the function passed to a sync.Once Do and where
+type onceCall struct {
+	fun	string
+	pos	token.Pos
+}
+
+func onceDoCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) != 1 || f.getMethod(call) != "(*sync.Once).Do" {
+		return;
+	}
+	once := mutexObj(f, call.Fun.(*ast.SelectorExpr).X);
+	if once == nil {
+		return;
+	}
+	// first calls are kept on the package so calls in different files are compared
+	if f.pkg.onceCalls == nil {
+		f.pkg.onceCalls = make(map[types.Object]onceCall);
+	}
+	calls := f.pkg.onceCalls;
+	// the same function is spelled the same way, o.Do(c.load) in two methods
+	fun := f.ASTString(call.Args[0]);
+	first, ok := calls[once];
+	if !ok {
+		calls[once] = onceCall{fun, call.Pos()};
+		return;
+	}
+	if first.fun != fun {
+		f.Reportf(call.Pos(), "%s.Do is also called with a different function at %s, only the first to run is ever called: %s", once.Name(), f.loc(first.pos), f.ASTString(call));
+	}
+	return;
+}
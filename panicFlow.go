@@ -0,0 +1,91 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+	"sort"
+)
+
+var panicFlow = flag.Bool("panic-flow", false, "report panic and recover used like exceptions for expected errors")
+
+func init() {
+	registerOptIn("panicFlow",
+		"this tests for panic and recover used like exceptions for expected errors",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *panicFlow;
+		},
+		panicFlowCheck,
+		funcDecl)
+}
+
+// thrownErrors returns the panics in a package whose value is an error
+// or another non string value, panic(err) rather than panic("unreachable")
+// the type info holds every call in the package, not just this file's
+func thrownErrors(f *File) []*ast.CallExpr {
+	var panics []*ast.CallExpr;
+	for x := range f.pkg.info.Types {
+		call, ok := x.(*ast.CallExpr);
+		if !ok || len(call.Args) != 1 || !isBuiltin(f, call, "panic") {
+			continue;
+		}
+		t := f.pkg.info.TypeOf(call.Args[0]);
+		if t == nil {
+			continue;
+		}
+		if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+			continue;
+		}
+		panics = append(panics, call);
+	}
+	// map order is random, report the first panic in the source
+	sort.Slice(panics, func(i, j int) bool {
+		return panics[i].Pos() < panics[j].Pos();
+	})
+	return panics;
+}
+
+// recoverDefer returns a defer in body calling a function literal that recovers
+func recoverDefer(f *File, body *ast.BlockStmt) *ast.DeferStmt {
+	var found *ast.DeferStmt;
+	ast.Inspect(body, func(node ast.Node) bool {
+		stmt, ok := node.(*ast.DeferStmt);
+		if !ok || found != nil {
+			return found == nil;
+		}
+		lit, ok := stmt.Call.Fun.(*ast.FuncLit);
+		if !ok {
+			return true;
+		}
+		ast.Inspect(lit.Body, func(node ast.Node) bool {
+			if call, ok := node.(*ast.CallExpr); ok && isBuiltin(f, call, "recover") {
+				found = stmt;
+			}
+			return found == nil;
+		})
+		return found == nil;
+	})
+	return found;
+}
+
+func panicFlowCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	stmt := recoverDefer(f, fun.Body);
+	if stmt == nil {
+		return;
+	}
+	panics := thrownErrors(f);
+	if len(panics) == 0 {
+		return;
+	}
+	f.Reportf(stmt.Pos(), "%s recovers panics and the package panics with error values such as at %s, return errors instead", fun.Name.Name, f.loc(panics[0].Pos()));
+	return;
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	profile = flag.String("profile", "all", "named set of checkers to run, see -list-profiles")
+	include = flag.String("include", "", "comma separated checkers to run in addition to the profile")
+	exclude = flag.String("exclude", "", "comma separated checkers not to run")
+	listProfiles = flag.Bool("list-profiles", false, "list the profiles and their checkers then exit")
+)
+
+// profiles maps a profile name to the categories of checkers it runs
+// a nil list means every category
+var profiles = map[string][]string{
+	"all":		nil,
+	"security":	{categorySecurity},
+	"correctness":	{categoryCorrectness},
+	"style":	{categoryStyle},
+	"performance":	{categoryPerformance},
+}
+
+// inProfile checks if a checker of the given category runs under a profile
+func inProfile(name, category string) bool {
+	categories := profiles[name];
+	if categories == nil {
+		return true;
+	}
+	for _, c := range categories {
+		if c == category {
+			return true;
+		}
+	}
+	return false;
+}
+
+// applyProfile sets which checkers report from -profile, -include and -exclude
+// opt in checkers are left out of every profile, their own flags work like -include
+func applyProfile() error {
+	if _, ok := profiles[*profile]; !ok {
+		return fmt.Errorf("unknown profile %s, see -list-profiles", *profile);
+	}
+	for name, c := range registered {
+		if c.optIn != nil {
+			report[name] = c.optIn();
+			continue;
+		}
+		report[name] = inProfile(*profile, c.category);
+	}
+	// -exclude comes last so it wins over -include
+	lists := []struct {
+		names	string
+		on	bool
+	}{
+		{*include, true},
+		{*exclude, false},
+	}
+	for _, list := range lists {
+		if list.names == "" {
+			continue;
+		}
+		for _, name := range strings.Split(list.names, ",") {
+			name = strings.TrimSpace(name);
+			if _, ok := registered[name]; !ok {
+				return fmt.Errorf("unknown checker %s", name);
+			}
+			report[name] = list.on;
+		}
+	}
+	return nil;
+}
+
+// printProfiles lists each profile with the checkers it runs,
+// then the opt in checkers no profile runs
+func printProfiles() {
+	var names []string;
+	for name := range profiles {
+		names = append(names, name);
+	}
+	sort.Strings(names);
+	for _, name := range names {
+		var enabled []string;
+		for checkerName, c := range registered {
+			if c.optIn == nil && inProfile(name, c.category) {
+				enabled = append(enabled, checkerName);
+			}
+		}
+		sort.Strings(enabled);
+		fmt.Printf("%s: %s\n", name, strings.Join(enabled, ", "));
+	}
+	var optIn []string;
+	for checkerName, c := range registered {
+		if c.optIn != nil {
+			optIn = append(optIn, checkerName);
+		}
+	}
+	sort.Strings(optIn);
+	fmt.Printf("off by default, run with -include: %s\n", strings.Join(optIn, ", "));
+}
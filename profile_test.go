@@ -0,0 +1,114 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestOptIn checks each opt in checker is off by default
+// and reports on its testdata file once included
+func TestOptIn(t *testing.T) {
+	for name, c := range registered {
+		if c.optIn == nil {
+			continue;
+		}
+		t.Run(name, func(t *testing.T) {
+			saveReport(t);
+			if err := applyProfile(); err != nil {
+				t.Fatal(err);
+			}
+			if report[name] {
+				t.Fatal("on by default");
+			}
+			setFlag(t, "include", name);
+			if err := applyProfile(); err != nil {
+				t.Fatal(err);
+			}
+			if !report[name] {
+				t.Fatal("not on with -include");
+			}
+			found := false;
+			for _, finding := range checkFiles(t, filepath.Join("testdata", name+".go")) {
+				found = found || finding.Checker == name;
+			}
+			if !found {
+				t.Errorf("no findings with -include %s", name);
+			}
+		});
+	}
+}
+
+// TestOptInFlags checks the old flags of opt in checkers still turn them on
+func TestOptInFlags(t *testing.T) {
+	tests := []struct {
+		flag	string
+		value	string
+		checker	string
+	}{
+		{"doc-comments", "true", "missingDoc"},
+		{"interface-size", "true", "interfaceSize"},
+		{"allow-any-params", "false", "anyParam"},
+		{"ctor-fields", "true", "ctorField"},
+		{"field-leaks", "true", "fieldLeak"},
+		{"global-env", "true", "globalEnv"},
+		{"panic-flow", "true", "panicFlow"},
+		{"strict-json", "true", "strictJSON"},
+		{"unexported-returns", "true", "unexportedReturn"},
+		{"wrap-errors", "true", "wrapErr"},
+	}
+	for _, test := range tests {
+		t.Run(test.flag, func(t *testing.T) {
+			saveReport(t);
+			setFlag(t, test.flag, test.value);
+			// the flag runs the checker whatever the profile
+			setFlag(t, "profile", "security");
+			if err := applyProfile(); err != nil {
+				t.Fatal(err);
+			}
+			if !report[test.checker] {
+				t.Errorf("-%s=%s does not run %s", test.flag, test.value, test.checker);
+			}
+		});
+	}
+}
+
+// TestProfile checks a profile runs only its category
+// and -include and -exclude adjust it, -exclude winning
+func TestProfile(t *testing.T) {
+	tests := []struct {
+		profile	string
+		include	string
+		exclude	string
+		on	[]string
+		off	[]string
+	}{
+		{"all", "", "", []string{"errorString", "tmpPath", "selfAssign"}, []string{"missingDoc"}},
+		{"security", "", "", []string{"tmpPath"}, []string{"errorString", "missingDoc", "selfAssign"}},
+		{"security", "errorString", "", []string{"tmpPath", "errorString"}, []string{"selfAssign"}},
+		{"security", "", "tmpPath", nil, []string{"tmpPath"}},
+		{"all", "errorString", "errorString", nil, []string{"errorString"}},
+		{"all", "errorString,tmpPath", "errorString", []string{"tmpPath"}, []string{"errorString"}},
+	}
+	for _, test := range tests {
+		saveReport(t);
+		setFlag(t, "profile", test.profile);
+		setFlag(t, "include", test.include);
+		setFlag(t, "exclude", test.exclude);
+		if err := applyProfile(); err != nil {
+			t.Fatal(err);
+		}
+		for _, name := range test.on {
+			if !report[name] {
+				t.Errorf("-profile=%s -include=%q -exclude=%q does not run %s", test.profile, test.include, test.exclude, name);
+			}
+		}
+		for _, name := range test.off {
+			if report[name] {
+				t.Errorf("-profile=%s -include=%q -exclude=%q runs %s", test.profile, test.include, test.exclude, name);
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("rangeMutate",
+		"this tests for maps changed inside a range loop over the same map",
+		severityMedium,
+		categoryCorrectness,
+		rangeMutateCheck,
+		rangeStmt)
+}
+
+func rangeMutateCheck(f *File, node ast.Node) {
+	loop, ok := node.(*ast.RangeStmt);
+	if !ok {
+		return;
+	}
+	obj := mapObj(f, loop.X);
+	if obj == nil {
+		return;
+	}
+	// deleting the current key is the well known safe way to filter a map
+	var key *ast.Ident;
+	if id, ok := loop.Key.(*ast.Ident); ok {
+		key = id;
+	}
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false;
+		case *ast.CallExpr:
+			if isBuiltin(f, n, "delete") && len(n.Args) == 2 && mapObj(f, n.Args[0]) == obj && f.ASTString(n.Args[0]) == f.ASTString(loop.X) {
+				if key != nil && refersTo(f, n.Args[1], f.pkg.info.ObjectOf(key)) {
+					return true;
+				}
+				f.Reportf(n.Pos(), "%s deletes from the map being ranged over, other keys may or may not be visited: %s", f.ASTString(loop.X), f.ASTString(n));
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				index, ok := lhs.(*ast.IndexExpr);
+				if !ok || mapObj(f, index.X) != obj || f.ASTString(index.X) != f.ASTString(loop.X) {
+					continue;
+				}
+				// updating the current key does not add entries
+				if key != nil && refersTo(f, index.Index, f.pkg.info.ObjectOf(key)) {
+					continue;
+				}
+				f.Reportf(lhs.Pos(), "%s adds to the map being ranged over, new keys may or may not be visited: %s", f.ASTString(loop.X), f.ASTString(lhs));
+			}
+		}
+		return true;
+	})
+	return;
+}
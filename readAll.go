@@ -11,6 +11,8 @@ import (
 func init() {
 	register("readAll",
 		"this tests checks of use of ioutil.ReadAll needs to be audited",
+		severityLow,
+		categorySecurity,
 		readAllCheck,
 		callExpr)
 }
@@ -0,0 +1,54 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("recover",
+		"this tests for panics recovered in deferred functions and then dropped",
+		severityMedium,
+		categoryCorrectness,
+		recoverCheck,
+		callExpr)
+}
+
+// isBuiltin checks if a call is to the named built in function
+// and not to something shadowing it
+func isBuiltin(f *File, call *ast.CallExpr, name string) bool {
+	id, ok := call.Fun.(*ast.Ident);
+	if !ok || id.Name != name {
+		return false;
+	}
+	if obj := f.pkg.info.Uses[id]; obj != nil {
+		_, ok = obj.(*types.Builtin);
+		return ok;
+	}
+	return true;
+}
+
+func recoverCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || !isBuiltin(f, call, "recover") {
+		return;
+	}
+	ignored := false;
+	switch p := f.parent().(type) {
+	case *ast.ExprStmt:
+		ignored = true;
+	case *ast.AssignStmt:
+		if len(p.Lhs) == 1 {
+			if id, ok := p.Lhs[0].(*ast.Ident); ok && id.Name == "_" {
+				ignored = true;
+			}
+		}
+	}
+	if _, deferred := f.funcLitStmt().(*ast.DeferStmt); ignored && deferred {
+		f.Reportf(call.Pos(), "panic recovered and dropped, log or handle the value from recover()");
+	}
+	return;
+}
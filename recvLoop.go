@@ -0,0 +1,51 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("recvLoop",
+		"this tests for infinite loops receiving from a channel without checking if it is closed",
+		severityLow,
+		categoryCorrectness,
+		recvLoopCheck,
+		forStmt)
+}
+
+// singleRecv returns the channel of v := <-ch or v = <-ch, not v, ok := <-ch
+func singleRecv(stmt ast.Stmt) ast.Expr {
+	assign, ok := stmt.(*ast.AssignStmt);
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil;
+	}
+	unary, ok := assign.Rhs[0].(*ast.UnaryExpr);
+	if !ok || unary.Op != token.ARROW {
+		return nil;
+	}
+	return unary.X;
+}
+
+func recvLoopCheck(f *File, node ast.Node) {
+	loop, ok := node.(*ast.ForStmt);
+	if !ok || loop.Cond != nil {
+		return;
+	}
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.SelectStmt, *ast.ForStmt, *ast.RangeStmt:
+			// select cases and inner loops are their own business
+			return false;
+		case ast.Stmt:
+			if ch := singleRecv(n); ch != nil {
+				f.Reportf(n.Pos(), "receive from %s in an infinite loop never sees the channel close, use for v := range %s or v, ok := <-%s", f.ASTString(ch), f.ASTString(ch), f.ASTString(ch));
+			}
+		}
+		return true;
+	})
+	return;
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+func init() {
+	register("reflectUnsafe",
+		"this tests for reflect.Value Set methods in files importing unsafe, which can write unexported fields",
+		severityMedium,
+		categorySecurity,
+		reflectUnsafeCheck,
+		callExpr)
+}
+
+// reflect refuses to set unexported fields, so setting values in a file
+// that also uses unsafe suggests reflect.NewAt or a pointer cast is
+// getting around that
+func reflectUnsafeCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || f.file == nil {
+		return;
+	}
+	method := f.getMethod(call);
+	if !strings.HasPrefix(method, "(reflect.Value).Set") {
+		return;
+	}
+	if _, imp := importSpec(f.file, "unsafe"); imp == nil {
+		return;
+	}
+	name := strings.TrimPrefix(method, "(reflect.Value).");
+	f.Reportf(call.Pos(), "reflect.Value.%s in a file importing unsafe, audit for writes to unexported fields: %s", name, f.ASTString(call));
+	return;
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("regexpLoop",
+		"this tests for regular expressions with constant patterns compiled inside loops",
+		severityLow,
+		categoryPerformance,
+		regexpLoopCheck,
+		callExpr)
+}
+
+func regexpLoopCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) != 1 || f.enclosingLoop() == nil {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	if path != "regexp" {
+		return;
+	}
+	switch name {
+	case "Compile", "MustCompile", "CompilePOSIX", "MustCompilePOSIX":
+	default:
+		return;
+	}
+	if !isConst(f, call.Args[0]) {
+		return;
+	}
+	f.Reportf(call.Pos(), "constant regexp compiled on every loop iteration, compile it once in a package level variable: %s", f.ASTString(call));
+	return;
+}
@@ -0,0 +1,308 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Finding is a single issue reported by a checker
+type Finding struct {
+	Pos		token.Position
+	Checker		string
+	Severity	string
+	Message		string
+	// Snippet is the source line and a caret, set with -snippet
+	Snippet		string	`json:",omitempty"`
+	// Fix holds the edits that fix the finding, if its checker can
+	Fix		[]Edit	`json:",omitempty"`
+	// Path is the absolute path of the file, Pos has the reported one
+	Path		string
+}
+
+// findings holds everything reported during the run
+var (
+	findings	[]Finding
+	findingsMu	sync.Mutex
+)
+
+// sortFindings puts findings in a stable order so output is the same
+// from run to run, by file, line, column, checker and then message
+func sortFindings(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j];
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename;
+		}
+		if a.Pos.Line != b.Pos.Line {
+			return a.Pos.Line < b.Pos.Line;
+		}
+		if a.Pos.Column != b.Pos.Column {
+			return a.Pos.Column < b.Pos.Column;
+		}
+		if a.Checker != b.Checker {
+			return a.Checker < b.Checker;
+		}
+		return a.Message < b.Message;
+	})
+}
+
+var severityOverride = flag.String("severity-override", "", "comma separated checker:severity pairs to reclassify checkers, e.g. weakKey:low,tmpPath:high")
+
+// applySeverityOverrides changes the severity of checkers named in -severity-override
+// before any are run, so every output format sees the new severity
+func applySeverityOverrides() error {
+	if *severityOverride == "" {
+		return nil;
+	}
+	for _, pair := range strings.Split(*severityOverride, ",") {
+		name, severity, ok := strings.Cut(strings.TrimSpace(pair), ":");
+		if !ok {
+			return fmt.Errorf("-severity-override entry %s is not checker:severity", pair);
+		}
+		c, ok := registered[name];
+		if !ok {
+			return fmt.Errorf("unknown checker %s", name);
+		}
+		if _, ok := severityRank[severity]; !ok {
+			return fmt.Errorf("unknown severity %s for %s, use low, medium or high", severity, name);
+		}
+		c.severity = severity;
+	}
+	return nil;
+}
+
+var quietCheckers = flag.String("quiet-checkers", "", "comma separated checkers whose findings are reported but do not fail the run")
+
+// checkQuietCheckers makes sure -quiet-checkers only names known checkers
+func checkQuietCheckers() error {
+	if *quietCheckers == "" {
+		return nil;
+	}
+	for _, name := range strings.Split(*quietCheckers, ",") {
+		if _, ok := registered[strings.TrimSpace(name)]; !ok {
+			return fmt.Errorf("unknown checker %s", name);
+		}
+	}
+	return nil;
+}
+
+// failing checks if any finding is from a checker not in -quiet-checkers
+func failing(findings []Finding) bool {
+	for _, finding := range findings {
+		if !inList(*quietCheckers, finding.Checker) {
+			return true;
+		}
+	}
+	return false;
+}
+
+// sameFinding checks if two findings report the same thing at the same place
+func sameFinding(a, b Finding) bool {
+	return a.Pos == b.Pos && a.Checker == b.Checker && a.Message == b.Message;
+}
+
+// dedupeFindings drops repeats of the same finding from sorted findings
+// different messages from one checker on a line are kept
+func dedupeFindings(findings []Finding) []Finding {
+	var deduped []Finding;
+	for i, finding := range findings {
+		if i > 0 && sameFinding(finding, findings[i-1]) {
+			continue;
+		}
+		deduped = append(deduped, finding);
+	}
+	return deduped;
+}
+
+// specificCheckers maps checkers that report some of another checker's
+// findings in more detail to the checker whose findings they repeat
+var specificCheckers = map[string]string{
+	"writeCheck":	"error",
+	"jsonError":	"error",
+}
+
+// preferSpecific drops a finding when a more specific checker reported the same line,
+// the checkers run on their own so //glasgo:ignore of one leaves the other reported
+func preferSpecific(findings []Finding) []Finding {
+	type fileLine struct {
+		file	string
+		line	int
+		checker	string
+	}
+	covered := make(map[fileLine]bool);
+	for _, finding := range findings {
+		if generic, ok := specificCheckers[finding.Checker]; ok {
+			covered[fileLine{finding.Pos.Filename, finding.Pos.Line, generic}] = true;
+		}
+	}
+	var kept []Finding;
+	for _, finding := range findings {
+		if !covered[fileLine{finding.Pos.Filename, finding.Pos.Line, finding.Checker}] {
+			kept = append(kept, finding);
+		}
+	}
+	return kept;
+}
+
+// formatters write out the findings once all files are checked
+var formatters = map[string]func(io.Writer, []Finding) error{
+	"text":		writeText,
+	"csv":		writeCSV,
+	"junit":	writeJUnit,
+	"ndjson":	writeNDJSON,
+}
+
+// severityRank orders severities from most to least severe
+var severityRank = map[string]int{
+	severityHigh:	0,
+	severityMedium:	1,
+	severityLow:	2,
+}
+
+// groupKey returns the -group-by group a finding belongs in
+func groupKey(finding Finding) string {
+	switch *groupBy {
+	case "checker":
+		return finding.Checker;
+	case "severity":
+		return finding.Severity;
+	}
+	return finding.Pos.Filename;
+}
+
+// writeText writes findings as text grouped by -group-by
+// files and checkers are sorted by name and severities from high to low
+func writeText(w io.Writer, findings []Finding) error {
+	var keys []string;
+	groups := make(map[string][]Finding);
+	for _, finding := range findings {
+		key := groupKey(finding);
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key);
+		}
+		groups[key] = append(groups[key], finding);
+	}
+	switch *groupBy {
+	case "file", "checker":
+		sort.Strings(keys);
+	case "severity":
+		sort.SliceStable(keys, func(i, j int) bool {
+			return severityRank[keys[i]] < severityRank[keys[j]];
+		})
+	}
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s\n", key); err != nil {
+			return err;
+		}
+		for _, finding := range groups[key] {
+			_, err := fmt.Fprintf(w, "\t* %s:%d %s \n", finding.Pos.Filename, finding.Pos.Line, finding.Message);
+			if err != nil {
+				return err;
+			}
+			if finding.Snippet == "" {
+				continue;
+			}
+			for _, line := range strings.Split(finding.Snippet, "\n") {
+				if _, err := fmt.Fprintf(w, "\t\t%s\n", line); err != nil {
+					return err;
+				}
+			}
+		}
+	}
+	return nil;
+}
+
+// writeCSV writes findings as comma separated values with a header row
+func writeCSV(w io.Writer, findings []Finding) error {
+	cw := csv.NewWriter(w);
+	if err := cw.Write([]string{"file", "line", "col", "checker", "severity", "message"}); err != nil {
+		return err;
+	}
+	for _, finding := range findings {
+		err := cw.Write([]string{
+			finding.Pos.Filename,
+			strconv.Itoa(finding.Pos.Line),
+			strconv.Itoa(finding.Pos.Column),
+			finding.Checker,
+			finding.Severity,
+			finding.Message,
+		});
+		if err != nil {
+			return err;
+		}
+	}
+	cw.Flush();
+	return cw.Error();
+}
+
+// JUnit XML elements, each finding is a failed test case
+// and each checker is a test suite
+type junitFailure struct {
+	Message	string	`xml:"message,attr"`
+	Type	string	`xml:"type,attr"`
+	Text	string	`xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name		string		`xml:"name,attr"`
+	ClassName	string		`xml:"classname,attr"`
+	Failure		junitFailure	`xml:"failure"`
+}
+
+type junitTestSuite struct {
+	Name		string		`xml:"name,attr"`
+	Tests		int		`xml:"tests,attr"`
+	Failures	int		`xml:"failures,attr"`
+	TestCases	[]junitTestCase	`xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName	xml.Name		`xml:"testsuites"`
+	Suites	[]*junitTestSuite	`xml:"testsuite"`
+}
+
+// writeJUnit writes findings as JUnit XML for CI test report dashboards
+func writeJUnit(w io.Writer, findings []Finding) error {
+	var suites junitTestSuites;
+	byChecker := make(map[string]*junitTestSuite);
+	for _, finding := range findings {
+		suite, ok := byChecker[finding.Checker];
+		if !ok {
+			suite = &junitTestSuite{Name: finding.Checker};
+			byChecker[finding.Checker] = suite;
+			suites.Suites = append(suites.Suites, suite);
+		}
+		suite.Tests++;
+		suite.Failures++;
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:		fmt.Sprintf("%s:%d", finding.Pos.Filename, finding.Pos.Line),
+			ClassName:	finding.Checker,
+			Failure:	junitFailure{
+				Message:	finding.Message,
+				Type:		finding.Severity,
+				Text:		fmt.Sprintf("%s: %s", finding.Pos, finding.Message),
+			},
+		});
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err;
+	}
+	enc := xml.NewEncoder(w);
+	enc.Indent("", "\t");
+	if err := enc.Encode(suites); err != nil {
+		return err;
+	}
+	_, err := io.WriteString(w, "\n");
+	return err;
+}
@@ -0,0 +1,434 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testFinding makes a finding for tests
+func testFinding(file string, line, col int, checker, message string) Finding {
+	return Finding{
+		Pos:		token.Position{Filename: file, Line: line, Column: col},
+		Checker:	checker,
+		Severity:	severityMedium,
+		Message:	message,
+	}
+}
+
+func TestSortFindings(t *testing.T) {
+	want := []Finding{
+		testFinding("a.go", 1, 1, "error", "x"),
+		testFinding("a.go", 1, 1, "error", "y"),
+		testFinding("a.go", 1, 1, "exit", "x"),
+		testFinding("a.go", 1, 5, "error", "x"),
+		testFinding("a.go", 2, 1, "error", "x"),
+		testFinding("a.go", 10, 1, "error", "x"),
+		testFinding("b.go", 1, 1, "error", "x"),
+	}
+	// every order sorts the same
+	for _, perm := range [][]int{
+		{0, 1, 2, 3, 4, 5, 6},
+		{6, 5, 4, 3, 2, 1, 0},
+		{3, 6, 0, 5, 2, 4, 1},
+	} {
+		got := make([]Finding, len(want));
+		for i, j := range perm {
+			got[i] = want[j];
+		}
+		sortFindings(got);
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("sorting %v:\ngot  %v\nwant %v", perm, got, want);
+		}
+	}
+}
+
+func TestSameFinding(t *testing.T) {
+	a := testFinding("a.go", 3, 2, "error", "error ignored f()");
+	tests := []struct {
+		b	Finding
+		same	bool
+	}{
+		{a, true},
+		{testFinding("b.go", 3, 2, "error", "error ignored f()"), false},
+		{testFinding("a.go", 4, 2, "error", "error ignored f()"), false},
+		{testFinding("a.go", 3, 3, "error", "error ignored f()"), false},
+		{testFinding("a.go", 3, 2, "jsonError", "error ignored f()"), false},
+		{testFinding("a.go", 3, 2, "error", "error ignored g()"), false},
+		// severity and snippet don't make a finding different
+		{Finding{Pos: a.Pos, Checker: a.Checker, Message: a.Message, Severity: severityHigh, Snippet: "f()"}, true},
+	}
+	for _, test := range tests {
+		if got := sameFinding(a, test.b); got != test.same {
+			t.Errorf("sameFinding(%v, %v) = %v, want %v", a, test.b, got, test.same);
+		}
+	}
+}
+
+func TestDedupeFindings(t *testing.T) {
+	tests := []struct {
+		name		string
+		findings	[]Finding
+		want		[]Finding
+	}{
+		{"none", nil, nil},
+		{"one", []Finding{testFinding("a.go", 1, 1, "error", "x")}, []Finding{testFinding("a.go", 1, 1, "error", "x")}},
+		{
+			"repeats",
+			[]Finding{
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 2, 1, "error", "x"),
+				testFinding("a.go", 2, 1, "error", "x"),
+			},
+			[]Finding{
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 2, 1, "error", "x"),
+			},
+		},
+		{
+			"different messages on a line are kept",
+			[]Finding{
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 1, 1, "error", "y"),
+				testFinding("a.go", 1, 1, "exit", "y"),
+			},
+			[]Finding{
+				testFinding("a.go", 1, 1, "error", "x"),
+				testFinding("a.go", 1, 1, "error", "y"),
+				testFinding("a.go", 1, 1, "exit", "y"),
+			},
+		},
+	}
+	for _, test := range tests {
+		if got := dedupeFindings(test.findings); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want);
+		}
+	}
+}
+
+// failWriter fails every write
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full");
+}
+
+func TestWriteCSV(t *testing.T) {
+	in := []Finding{
+		testFinding("a.go", 1, 2, "error", "plain"),
+		testFinding("dir, with comma/b.go", 3, 4, "dynFormat", `format "%s" from, a variable`),
+		testFinding("c.go", 5, 6, "hardcodedToken", "two\nlines"),
+	}
+	var b bytes.Buffer;
+	if err := writeCSV(&b, in); err != nil {
+		t.Fatal(err);
+	}
+	records, err := csv.NewReader(&b).ReadAll();
+	if err != nil {
+		t.Fatalf("output does not parse as csv: %s", err);
+	}
+	want := [][]string{
+		{"file", "line", "col", "checker", "severity", "message"},
+		{"a.go", "1", "2", "error", "medium", "plain"},
+		{"dir, with comma/b.go", "3", "4", "dynFormat", "medium", `format "%s" from, a variable`},
+		{"c.go", "5", "6", "hardcodedToken", "medium", "two\nlines"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %q, want %q", records, want);
+	}
+	if err := writeCSV(failWriter{}, in); err == nil {
+		t.Error("no error from a failing writer");
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	tests := []struct {
+		name		string
+		findings	[]Finding
+		suites		map[string]int
+	}{
+		{"empty", nil, map[string]int{}},
+		{
+			"escaped",
+			[]Finding{
+				testFinding("a.go", 1, 1, "error", `error ignored f(a < b && c > "d")`),
+				testFinding("a.go", 2, 1, "error", "error ignored g('x')"),
+				testFinding("b.go", 3, 1, "exit", "control \x01 character"),
+			},
+			map[string]int{"error": 2, "exit": 1},
+		},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer;
+		if err := writeJUnit(&b, test.findings); err != nil {
+			t.Fatal(err);
+		}
+		var suites junitTestSuites;
+		if err := xml.Unmarshal(b.Bytes(), &suites); err != nil {
+			t.Fatalf("%s: output is not valid XML: %s\n%s", test.name, err, b.String());
+		}
+		got := make(map[string]int);
+		var messages []string;
+		for _, suite := range suites.Suites {
+			got[suite.Name] = suite.Failures;
+			for _, tc := range suite.TestCases {
+				messages = append(messages, tc.Failure.Message);
+			}
+		}
+		if !reflect.DeepEqual(got, test.suites) {
+			t.Errorf("%s: got suites %v, want %v", test.name, got, test.suites);
+		}
+		for i, message := range messages {
+			// XML can't hold control characters, they come back as U+FFFD
+			want := strings.ReplaceAll(test.findings[i].Message, "\x01", "�");
+			if message != want {
+				t.Errorf("%s: got message %q, want %q", test.name, message, want);
+			}
+		}
+	}
+}
+
+func TestGroupKey(t *testing.T) {
+	f := testFinding("a.go", 1, 1, "exit", "x");
+	f.Severity = severityHigh;
+	for groupBy, want := range map[string]string{"file": "a.go", "checker": "exit", "severity": severityHigh} {
+		setFlag(t, "group-by", groupBy);
+		if got := groupKey(f); got != want {
+			t.Errorf("-group-by=%s: groupKey = %q, want %q", groupBy, got, want);
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	low := testFinding("b.go", 2, 1, "ioutil", "deprecated");
+	low.Severity = severityLow;
+	high := testFinding("b.go", 5, 1, "weakKey", "short key");
+	high.Severity = severityHigh;
+	medium := testFinding("a.go", 3, 1, "error", "ignored");
+	medium.Snippet = "f()\n^";
+	in := []Finding{low, high, medium};
+	tests := []struct {
+		groupBy	string
+		want	string
+	}{
+		{"file", "a.go\n\t* a.go:3 ignored \n\t\tf()\n\t\t^\nb.go\n\t* b.go:2 deprecated \n\t* b.go:5 short key \n"},
+		{"checker", "error\n\t* a.go:3 ignored \n\t\tf()\n\t\t^\nioutil\n\t* b.go:2 deprecated \nweakKey\n\t* b.go:5 short key \n"},
+		{"severity", "high\n\t* b.go:5 short key \nmedium\n\t* a.go:3 ignored \n\t\tf()\n\t\t^\nlow\n\t* b.go:2 deprecated \n"},
+	}
+	for _, test := range tests {
+		setFlag(t, "group-by", test.groupBy);
+		var b bytes.Buffer;
+		if err := writeText(&b, in); err != nil {
+			t.Fatal(err);
+		}
+		if b.String() != test.want {
+			t.Errorf("-group-by=%s:\n%s\nwant:\n%s", test.groupBy, b.String(), test.want);
+		}
+	}
+}
+
+// TestDeterministicOutput checks that the same files give byte identical
+// output however the checking goes, here with the files in another order
+func TestDeterministicOutput(t *testing.T) {
+	names := []string{
+		filepath.Join("testdata", "errors.go"),
+		filepath.Join("testdata", "selfAssign.go"),
+		filepath.Join("testdata", "tautology.go"),
+		filepath.Join("testdata", "dupCase.go"),
+	}
+	var outputs []string;
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}} {
+		var files []string;
+		for _, i := range order {
+			files = append(files, names[i]);
+		}
+		found := runCheckers(t, "error,selfAssign,tautology,dupCase", files...);
+		sortFindings(found);
+		var b bytes.Buffer;
+		if err := writeCSV(&b, dedupeFindings(found)); err != nil {
+			t.Fatal(err);
+		}
+		outputs = append(outputs, b.String());
+	}
+	if strings.Count(outputs[0], "\n") < 3 {
+		t.Fatalf("too few findings to compare:\n%s", outputs[0]);
+	}
+	if outputs[0] != outputs[1] {
+		t.Errorf("output differs between runs:\n%s\nand:\n%s", outputs[0], outputs[1]);
+	}
+}
+
+func TestApplySeverityOverrides(t *testing.T) {
+	saved := make(map[string]string);
+	for name, c := range registered {
+		saved[name] = c.severity;
+	}
+	restore := func() {
+		for name, severity := range saved {
+			registered[name].severity = severity;
+		}
+	}
+	defer restore();
+	tests := []struct {
+		override	string
+		want		map[string]string
+		err		bool
+	}{
+		{"", map[string]string{"weakKey": saved["weakKey"]}, false},
+		{"weakKey:low", map[string]string{"weakKey": severityLow, "tmpPath": saved["tmpPath"]}, false},
+		{"weakKey:low, tmpPath:high", map[string]string{"weakKey": severityLow, "tmpPath": severityHigh}, false},
+		{"weakKey", nil, true},
+		{"noSuchChecker:low", nil, true},
+		{"weakKey:urgent", nil, true},
+	}
+	for _, test := range tests {
+		restore();
+		setFlag(t, "severity-override", test.override);
+		err := applySeverityOverrides();
+		if (err != nil) != test.err {
+			t.Errorf("-severity-override=%q: error = %v, want error %v", test.override, err, test.err);
+			continue;
+		}
+		for name, want := range test.want {
+			if got := registered[name].severity; got != want {
+				t.Errorf("-severity-override=%q: %s is %s, want %s", test.override, name, got, want);
+			}
+		}
+	}
+	// findings take the new severity
+	restore();
+	setFlag(t, "severity-override", "selfAssign:high");
+	if err := applySeverityOverrides(); err != nil {
+		t.Fatal(err);
+	}
+	found := runCheckers(t, "selfAssign", filepath.Join("testdata", "selfAssign.go"));
+	if len(found) == 0 {
+		t.Fatal("no findings");
+	}
+	for _, finding := range found {
+		if finding.Severity != severityHigh {
+			t.Errorf("finding %v is not high", finding);
+		}
+	}
+}
+
+func TestFailing(t *testing.T) {
+	exit := testFinding("a.go", 1, 1, "exit", "x");
+	weakKey := testFinding("a.go", 2, 1, "weakKey", "x");
+	tests := []struct {
+		quiet		string
+		findings	[]Finding
+		want		bool
+	}{
+		{"", nil, false},
+		{"", []Finding{exit}, true},
+		{"exit", []Finding{exit}, false},
+		{"exit", []Finding{exit, weakKey}, true},
+		{"exit, weakKey", []Finding{exit, weakKey}, false},
+		{"weakKey", []Finding{exit}, true},
+	}
+	for _, test := range tests {
+		setFlag(t, "quiet-checkers", test.quiet);
+		if got := failing(test.findings); got != test.want {
+			t.Errorf("-quiet-checkers=%q: failing(%v) = %v, want %v", test.quiet, test.findings, got, test.want);
+		}
+	}
+}
+
+func TestCheckQuietCheckers(t *testing.T) {
+	for quiet, err := range map[string]bool{"": false, "exit": false, "exit, weakKey": false, "exit,noSuchChecker": true} {
+		setFlag(t, "quiet-checkers", quiet);
+		if got := checkQuietCheckers(); (got != nil) != err {
+			t.Errorf("-quiet-checkers=%q: error = %v, want error %v", quiet, got, err);
+		}
+	}
+}
+
+func TestPreferSpecific(t *testing.T) {
+	tests := []struct {
+		name		string
+		findings	[]Finding
+		want		[]Finding
+	}{
+		{
+			"generic dropped on the same line",
+			[]Finding{testFinding("a.go", 3, 2, "error", "error ignored w.Write(b)"), testFinding("a.go", 3, 2, "writeCheck", "short write")},
+			[]Finding{testFinding("a.go", 3, 2, "writeCheck", "short write")},
+		},
+		{
+			"other lines and files kept",
+			[]Finding{
+				testFinding("a.go", 4, 2, "error", "error ignored f()"),
+				testFinding("b.go", 3, 2, "error", "error ignored w.Write(b)"),
+				testFinding("a.go", 3, 2, "writeCheck", "short write"),
+			},
+			[]Finding{
+				testFinding("a.go", 4, 2, "error", "error ignored f()"),
+				testFinding("b.go", 3, 2, "error", "error ignored w.Write(b)"),
+				testFinding("a.go", 3, 2, "writeCheck", "short write"),
+			},
+		},
+		{
+			"json errors",
+			[]Finding{testFinding("a.go", 5, 2, "error", "error ignored _ json.Marshal(v)"), testFinding("a.go", 5, 2, "jsonError", "json.Marshal error assigned to _")},
+			[]Finding{testFinding("a.go", 5, 2, "jsonError", "json.Marshal error assigned to _")},
+		},
+		{
+			"other checkers on the line kept",
+			[]Finding{testFinding("a.go", 3, 2, "exit", "os.Exit"), testFinding("a.go", 3, 2, "writeCheck", "short write")},
+			[]Finding{testFinding("a.go", 3, 2, "exit", "os.Exit"), testFinding("a.go", 3, 2, "writeCheck", "short write")},
+		},
+	}
+	for _, test := range tests {
+		if got := preferSpecific(test.findings); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want);
+		}
+	}
+}
+
+// TestPreferSpecificIgnored checks ignoring the specific checker on a line
+// leaves the generic finding reported
+func TestPreferSpecificIgnored(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "write.go");
+	src := `package p
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func write(w io.Writer, b []byte) {
+	w.Write(b)
+	//glasgo:ignore writeCheck
+	w.Write(b)
+	_, _ = json.Marshal(b)
+	//glasgo:ignore jsonError
+	_, _ = json.Marshal(b)
+}
+`;
+	if err := os.WriteFile(name, []byte(src), 0644); err != nil {
+		t.Fatal(err);
+	}
+	found := runCheckers(t, "error,writeCheck,jsonError", name);
+	sortFindings(found);
+	var got []string;
+	for _, finding := range preferSpecific(found) {
+		got = append(got, fmt.Sprintf("%d %s", finding.Pos.Line, finding.Checker));
+	}
+	if want := []string{"9 writeCheck", "11 error", "12 jsonError", "14 error"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want);
+	}
+}
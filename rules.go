@@ -0,0 +1,41 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+var rulesDir = flag.String("rules-dir", "", "directory of Go plugins (.so) with extra checkers to load")
+
+// PluginCheck is the checker function a plugin provides.
+// It is called with each node of the registered types and the type
+// information for the package, and calls report for each issue found.
+//
+// glasgo is a main package so plugins can't import its types.
+// The contract only uses the standard library instead.
+// A plugin exports a function
+//
+//	func Register(register func(name, usage, severity string, check func(ast.Node, *types.Info, func(token.Pos, string)), types ...ast.Node))
+//
+// and calls register once for each checker, passing nil pointers
+// such as (*ast.CallExpr)(nil) for the node types to check.
+type PluginCheck = func(node ast.Node, info *types.Info, report func(pos token.Pos, msg string))
+
+// PluginRegister is the type of the Register symbol exported by a plugin
+type PluginRegister = func(register func(name, usage, severity string, check PluginCheck, types ...ast.Node))
+
+// registerPlugin adds a checker from a plugin to the registered checkers
+func registerPlugin(name, usage, severity string, check PluginCheck, types ...ast.Node) {
+	fn := func(f *File, node ast.Node) {
+		check(node, f.pkg.info, func(pos token.Pos, msg string) {
+			f.Reportf(pos, "%s", msg);
+		})
+	}
+	// plugins are mostly an organisation's own security rules
+	register(name, usage, severity, categorySecurity, fn, types...);
+}
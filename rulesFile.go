@@ -0,0 +1,89 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"os"
+	"strings"
+)
+
+var rulesFile = flag.String("rules-file", "", "JSON file of declarative rules banning calls, see README")
+
+// Rule is a declarative checker from -rules-file, e.g.
+//
+//	{"name": "noMD5", "node": "CallExpr", "call": "crypto/md5.New",
+//	 "message": "md5 is broken, use sha256", "severity": "high"}
+//
+// call is an import path and function name, or a method
+// as types prints it such as "(*database/sql.DB).Query"
+type Rule struct {
+	Name		string	`json:"name"`
+	Node		string	`json:"node"`
+	Call		string	`json:"call"`
+	Message		string	`json:"message"`
+	Severity	string	`json:"severity"`
+	Category	string	`json:"category"`
+}
+
+// callName returns the import path qualified name of a call
+// matching the form rules are written in
+func callName(f *File, call *ast.CallExpr) string {
+	if path, name := f.getPkgFunc(call); path != "" {
+		return path + "." + name;
+	}
+	return f.getMethod(call);
+}
+
+// loadRulesFile registers a checker for each rule in a rules file
+func loadRulesFile(name string) error {
+	data, err := os.ReadFile(name);
+	if err != nil {
+		return err;
+	}
+	var rules []Rule;
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("%s: %s", name, err);
+	}
+	for _, rule := range rules {
+		if rule.Name == "" || rule.Call == "" {
+			return fmt.Errorf("%s: rules need a name and a call", name);
+		}
+		if _, ok := registered[rule.Name]; ok {
+			return fmt.Errorf("%s: rule %s has the name of an existing checker", name, rule.Name);
+		}
+		// calls are the only node type matched so far
+		if rule.Node != "" && rule.Node != "CallExpr" {
+			return fmt.Errorf("%s: rule %s has unsupported node %s, only CallExpr is supported", name, rule.Name, rule.Node);
+		}
+		if rule.Severity == "" {
+			rule.Severity = severityMedium;
+		}
+		if _, ok := severityRank[rule.Severity]; !ok {
+			return fmt.Errorf("%s: rule %s has unknown severity %s", name, rule.Name, rule.Severity);
+		}
+		if rule.Category == "" {
+			rule.Category = categorySecurity;
+		}
+		if _, ok := profiles[rule.Category]; !ok || rule.Category == "all" {
+			return fmt.Errorf("%s: rule %s has unknown category %s", name, rule.Name, rule.Category);
+		}
+		if rule.Message == "" {
+			rule.Message = "call to " + rule.Call + " is not allowed";
+		}
+		rule := rule;
+		fn := func(f *File, node ast.Node) {
+			call, ok := node.(*ast.CallExpr);
+			if ok && callName(f, call) == rule.Call {
+				f.Reportf(call.Pos(), "%s: %s", rule.Message, f.ASTString(call));
+			}
+		}
+		usage := "this tests for calls to " + strings.TrimSpace(rule.Call) + ", from " + name;
+		register(rule.Name, usage, rule.Severity, rule.Category, fn, callExpr);
+	}
+	return nil;
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const rulesTestSrc = `package p
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"strings"
+)
+
+func sums(b *strings.Builder) {
+	md5.New()
+	sha256.New()
+	b.WriteString("x")
+}
+`
+
+// writeRules writes a rules file and loads it, unregistering its rules at the end
+func writeRules(t *testing.T, rules string) error {
+	t.Helper();
+	name := filepath.Join(t.TempDir(), "rules.json");
+	if err := os.WriteFile(name, []byte(rules), 0644); err != nil {
+		t.Fatal(err);
+	}
+	before := make(map[string]bool);
+	for checker := range registered {
+		before[checker] = true;
+	}
+	t.Cleanup(func() {
+		for checker := range registered {
+			if !before[checker] {
+				unregister(checker);
+			}
+		}
+	});
+	return loadRulesFile(name);
+}
+
+// TestRulesFile checks rules flag the calls they ban and nothing else
+func TestRulesFile(t *testing.T) {
+	err := writeRules(t, `[
+		{"name": "noMD5", "node": "CallExpr", "call": "crypto/md5.New", "message": "md5 is broken", "severity": "high"},
+		{"name": "noBuilder", "call": "(*strings.Builder).WriteString", "category": "correctness"}
+	]`);
+	if err != nil {
+		t.Fatal(err);
+	}
+	if c := registered["noBuilder"]; c == nil || c.severity != severityMedium || c.category != categoryCorrectness {
+		t.Fatalf("noBuilder registered as %+v, want medium correctness", c);
+	}
+	name := filepath.Join(t.TempDir(), "p.go");
+	if err := os.WriteFile(name, []byte(rulesTestSrc), 0644); err != nil {
+		t.Fatal(err);
+	}
+	got := make(map[string]Finding);
+	for _, finding := range runCheckers(t, "noMD5,noBuilder", name) {
+		got[finding.Checker] = finding;
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %v, want a finding from each rule", got);
+	}
+	if md5 := got["noMD5"]; md5.Pos.Line != 10 || md5.Severity != severityHigh || md5.Message != "md5 is broken: md5.New()" {
+		t.Errorf("got noMD5 finding %+v", md5);
+	}
+	if builder := got["noBuilder"]; builder.Pos.Line != 12 || builder.Message != `call to (*strings.Builder).WriteString is not allowed: b.WriteString("x")` {
+		t.Errorf("got noBuilder finding %+v", builder);
+	}
+}
+
+func TestRulesFileErrors(t *testing.T) {
+	tests := []struct {
+		name	string
+		rules	string
+	}{
+		{"not json", `{`},
+		{"no name", `[{"call": "os.Exit"}]`},
+		{"no call", `[{"name": "noExit"}]`},
+		{"existing checker", `[{"name": "exit", "call": "os.Exit"}]`},
+		{"unsupported node", `[{"name": "noExit", "node": "FuncDecl", "call": "os.Exit"}]`},
+		{"unknown severity", `[{"name": "noExit", "call": "os.Exit", "severity": "urgent"}]`},
+		{"unknown category", `[{"name": "noExit", "call": "os.Exit", "category": "all"}]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := writeRules(t, test.rules); err == nil {
+				t.Error("no error");
+			}
+		});
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package main
+
+// loadRules can't load plugins on this platform
+func loadRules(dir string) {
+	warnf("-rules-dir is not supported on this platform, plugins need linux, darwin or freebsd with cgo");
+}
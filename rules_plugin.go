@@ -0,0 +1,38 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+//go:build (linux || darwin || freebsd) && cgo
+
+package main
+
+import (
+	"path/filepath"
+	"plugin"
+)
+
+// loadRules opens every plugin in dir and lets it register its checkers
+func loadRules(dir string) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.so"));
+	if err != nil {
+		warnf("error reading rules directory %s: %s", dir, err);
+		return;
+	}
+	for _, name := range names {
+		p, err := plugin.Open(name);
+		if err != nil {
+			warnf("error loading plugin %s: %s", name, err);
+			continue;
+		}
+		sym, err := p.Lookup("Register");
+		if err != nil {
+			warnf("plugin %s has no Register function: %s", name, err);
+			continue;
+		}
+		reg, ok := sym.(PluginRegister);
+		if !ok {
+			warnf("plugin %s: Register has type %T, want %T", name, sym, PluginRegister(nil));
+			continue;
+		}
+		reg(registerPlugin);
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+func init() {
+	register("secretFile",
+		"this tests for secrets written to files other users can read",
+		severityHigh,
+		categorySecurity,
+		secretFileCheck,
+		callExpr)
+}
+
+// secretWords are parts of variable and field names that hold secrets
+var secretWords = []string{"secret", "password", "passwd", "token", "apikey", "api_key", "privatekey", "private_key", "credential"}
+
+// secretName checks if a variable or field name suggests it holds a secret
+func secretName(name string) bool {
+	name = strings.ToLower(name);
+	for _, word := range secretWords {
+		if strings.Contains(name, word) {
+			return true;
+		}
+	}
+	return false;
+}
+
+// secretData checks if x is a secret named variable or field,
+// a conversion of one like []byte(password)
+// or a variable assigned from one in the enclosing function
+func secretData(f *File, x ast.Expr, depth int) bool {
+	if depth > 3 {
+		return false;
+	}
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return secretData(f, x.X, depth);
+	case *ast.SelectorExpr:
+		return secretName(x.Sel.Name);
+	case *ast.CallExpr:
+		// only conversions, a call on a secret may hash or encrypt it
+		if tv, ok := f.pkg.info.Types[x.Fun]; ok && tv.IsType() && len(x.Args) == 1 {
+			return secretData(f, x.Args[0], depth+1);
+		}
+	case *ast.Ident:
+		if secretName(x.Name) {
+			return true;
+		}
+		obj := f.pkg.info.ObjectOf(x);
+		fun := f.enclosingFunc();
+		if obj == nil || fun == nil {
+			return false;
+		}
+		found := false;
+		ast.Inspect(fun, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt);
+			if !ok || found {
+				return !found;
+			}
+			if len(assign.Lhs) != len(assign.Rhs) {
+				return true;
+			}
+			for i, lhs := range assign.Lhs {
+				if refersTo(f, lhs, obj) && secretData(f, assign.Rhs[i], depth+1) {
+					found = true;
+				}
+			}
+			return true;
+		});
+		return found;
+	}
+	return false;
+}
+
+// broadMode checks if a constant file mode lets the group or others in
+// it returns false when the mode is not a constant
+func broadMode(f *File, x ast.Expr) (int64, bool) {
+	mode, ok := constInt(f, x);
+	return mode, ok && mode&0077 != 0;
+}
+
+// writesSecret checks if the file obj is written a secret anywhere in fun
+// with file.Write, file.WriteString, io.WriteString or fmt.Fprint
+func writesSecret(f *File, fun ast.Node, file *ast.Ident) bool {
+	obj := f.pkg.info.ObjectOf(file);
+	if obj == nil {
+		return false;
+	}
+	found := false;
+	ast.Inspect(fun, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr);
+		if !ok || found || len(call.Args) == 0 {
+			return !found;
+		}
+		var data []ast.Expr;
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && refersTo(f, sel.X, obj) {
+			switch sel.Sel.Name {
+			case "Write", "WriteString":
+				data = call.Args[:1];
+			}
+		}
+		path, name := f.getPkgFunc(call);
+		if (path == "io" && name == "WriteString") || (path == "fmt" && strings.HasPrefix(name, "Fprint")) {
+			if refersTo(f, call.Args[0], obj) {
+				data = call.Args[1:];
+			}
+		}
+		for _, arg := range data {
+			if secretData(f, arg, 0) {
+				found = true;
+			}
+		}
+		return !found;
+	});
+	return found;
+}
+
+func secretFileCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || len(call.Args) != 3 {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	switch {
+	case (path == "os" || path == "io/ioutil") && name == "WriteFile":
+		mode, broad := broadMode(f, call.Args[2]);
+		if broad && secretData(f, call.Args[1], 0) {
+			f.Reportf(call.Pos(), "secret written to a file with mode %#o, use 0600: %s", mode, f.ASTString(call));
+		}
+	case path == "os" && name == "OpenFile":
+		mode, broad := broadMode(f, call.Args[2]);
+		if !broad {
+			return;
+		}
+		// file, err := os.OpenFile(...)
+		assign, ok := f.parent().(*ast.AssignStmt);
+		if !ok || len(assign.Rhs) != 1 {
+			return;
+		}
+		file, ok := assign.Lhs[0].(*ast.Ident);
+		fun := f.enclosingFunc();
+		if !ok || fun == nil {
+			return;
+		}
+		if writesSecret(f, fun, file) {
+			f.Reportf(call.Pos(), "secret written to a file opened with mode %#o, use 0600: %s", mode, f.ASTString(call));
+		}
+	}
+	return;
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("selfAssign",
+		"this tests for assignments of a value to itself",
+		severityMedium,
+		categoryCorrectness,
+		selfAssignCheck,
+		assignStmt)
+}
+
+func selfAssignCheck(f *File, node ast.Node) {
+	assign, ok := node.(*ast.AssignStmt);
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
+		return;
+	}
+	for i, lhs := range assign.Lhs {
+		rhs := assign.Rhs[i];
+		// pureExpr leaves out calls and receives which may give a new value
+		if !pureExpr(f, rhs) || f.ASTString(lhs) != f.ASTString(rhs) {
+			continue;
+		}
+		f.Reportf(lhs.Pos(), "self assignment %s = %s does nothing", f.ASTString(lhs), f.ASTString(rhs));
+	}
+	return;
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("shadowErr",
+		"this tests for error variables declared with := that shadow an outer error still in use",
+		severityMedium,
+		categoryCorrectness,
+		shadowErrCheck,
+		assignStmt)
+}
+
+// usedAfter checks if obj is used anywhere after pos
+func usedAfter(f *File, obj types.Object, pos token.Pos) bool {
+	for id, used := range f.pkg.info.Uses {
+		if used == obj && id.Pos() > pos {
+			return true;
+		}
+	}
+	return false;
+}
+
+func shadowErrCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.AssignStmt);
+	if !ok || stmt.Tok != token.DEFINE {
+		return;
+	}
+	for _, lhs := range stmt.Lhs {
+		id, ok := lhs.(*ast.Ident);
+		if !ok {
+			continue;
+		}
+		// redeclared variables are uses, not definitions
+		obj := f.pkg.info.Defs[id];
+		if obj == nil || obj.Parent() == nil || obj.Parent().Parent() == nil || !isError(obj.Type()) {
+			continue;
+		}
+		_, outer := obj.Parent().Parent().LookupParent(id.Name, id.Pos());
+		if v, ok := outer.(*types.Var); !ok || !isError(v.Type()) || v.Parent() == f.pkg.typePkg.Scope() {
+			continue;
+		}
+		// the shadowing only matters if the outer error is checked later
+		if usedAfter(f, outer, obj.Parent().End()) {
+			f.Reportf(id.Pos(), "%s shadows the %s declared at %s", id.Name, id.Name, f.loc(outer.Pos()));
+		}
+	}
+	return;
+}
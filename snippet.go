@@ -0,0 +1,67 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"sync"
+)
+
+var snippet = flag.Bool("snippet", false, "show the source line and a caret under the column with each text finding")
+
+// sourceLines caches the lines of files already read for snippets
+var (
+	sourceLines	= make(map[string][]string)
+	sourceLinesMu	sync.Mutex
+)
+
+// sourceLine returns a line of a file, or false when the file
+// cannot be read again such as when it came from stdin
+func sourceLine(name string, line int) (string, bool) {
+	sourceLinesMu.Lock();
+	defer sourceLinesMu.Unlock();
+	lines, ok := sourceLines[name];
+	if !ok {
+		data, err := os.ReadFile(name);
+		if err == nil {
+			lines = strings.Split(string(data), "\n");
+		}
+		// failures are cached too so a missing file is only tried once
+		sourceLines[name] = lines;
+	}
+	if line < 1 || line > len(lines) {
+		return "", false;
+	}
+	return strings.TrimRight(lines[line-1], "\r"), true;
+}
+
+// caretLine returns a line with a caret under the 1 based byte column of src
+// tabs are kept so the caret lines up however wide the terminal draws them
+func caretLine(src string, column int) string {
+	var b strings.Builder
+	for i, r := range src {
+		if i >= column-1 {
+			break;
+		}
+		if r == '\t' {
+			b.WriteRune('\t');
+		} else {
+			b.WriteRune(' ');
+		}
+	}
+	b.WriteRune('^');
+	return b.String();
+}
+
+// snippetFor returns the source line and caret for a position
+// or "" if the line cannot be read
+func snippetFor(name string, line, column int) string {
+	src, ok := sourceLine(name, line);
+	if !ok {
+		return "";
+	}
+	return src + "\n" + caretLine(src, column);
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCaretLine(t *testing.T) {
+	tests := []struct {
+		src	string
+		column	int
+		want	string
+	}{
+		{"x = x", 1, "^"},
+		{"x = x", 5, "    ^"},
+		// tabs are kept so the caret lines up
+		{"\t\tx = x", 3, "\t\t^"},
+		{"\tif a {", 5, "\t   ^"},
+		// columns count bytes, a multi byte rune takes one space
+		{`s := "é" + x`, 13, "           ^"},
+		// past the end of the line the caret follows the line
+		{"ab", 10, "  ^"},
+		{"", 1, "^"},
+	}
+	for _, test := range tests {
+		if got := caretLine(test.src, test.column); got != test.want {
+			t.Errorf("caretLine(%q, %d) = %q, want %q", test.src, test.column, got, test.want);
+		}
+	}
+}
+
+func TestSnippetFor(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "snippet.go");
+	if err := os.WriteFile(name, []byte("package main\r\n\tx = x\r\n"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	if got, want := snippetFor(name, 2, 6), "\tx = x\n\t    ^"; got != want {
+		t.Errorf("snippetFor = %q, want %q", got, want);
+	}
+	if got := snippetFor(name, 9, 1); got != "" {
+		t.Errorf("snippetFor past the end = %q, want none", got);
+	}
+	if got := snippetFor(filepath.Join(t.TempDir(), "missing.go"), 1, 1); got != "" {
+		t.Errorf("snippetFor a missing file = %q, want none", got);
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	register("sqlClose",
+		"this tests if a database opened with sql.Open is closed and pinged",
+		severityMedium,
+		categoryCorrectness,
+		sqlCloseCheck,
+		funcDecl)
+}
+
+// refersTo checks if an expression is an identifier for the object obj
+func refersTo(f *File, x ast.Expr, obj types.Object) bool {
+	id, ok := x.(*ast.Ident);
+	return ok && obj != nil && f.pkg.info.ObjectOf(id) == obj;
+}
+
+// dbUses looks through a function body for what happens to the database obj
+// it returns if Close and Ping are called and if it escapes the function
+// by being returned or stored in a struct
+func dbUses(f *File, body *ast.BlockStmt, obj types.Object) (closed, pinged, escapes bool) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && refersTo(f, sel.X, obj) {
+				switch sel.Sel.Name {
+				case "Close":
+					closed = true;
+				case "Ping", "PingContext":
+					pinged = true;
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, x := range n.Results {
+				if refersTo(f, x, obj) {
+					escapes = true;
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if _, ok := lhs.(*ast.SelectorExpr); ok && i < len(n.Rhs) && refersTo(f, n.Rhs[i], obj) {
+					escapes = true;
+				}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value;
+				}
+				if refersTo(f, elt, obj) {
+					escapes = true;
+				}
+			}
+		}
+		return true;
+	})
+	return closed, pinged, escapes;
+}
+
+func sqlCloseCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Body == nil {
+		return;
+	}
+	ast.Inspect(fun.Body, func(node ast.Node) bool {
+		stmt, ok := node.(*ast.AssignStmt);
+		if !ok || len(stmt.Rhs) != 1 {
+			return true;
+		}
+		call, ok := stmt.Rhs[0].(*ast.CallExpr);
+		if !ok {
+			return true;
+		}
+		if path, name := f.getPkgFunc(call); path != "database/sql" || name != "Open" {
+			return true;
+		}
+		id, ok := stmt.Lhs[0].(*ast.Ident);
+		if !ok || id.Name == "_" {
+			return true;
+		}
+		closed, pinged, escapes := dbUses(f, fun.Body, f.pkg.info.ObjectOf(id));
+		if escapes {
+			return true;
+		}
+		if !closed {
+			f.Reportf(stmt.Pos(), "database opened but never closed, %s", f.ASTString(call));
+		}
+		if !pinged {
+			f.Reportf(stmt.Pos(), "database opened but never pinged, connection errors go unnoticed, %s", f.ASTString(call));
+		}
+		return true;
+	})
+	return;
+}
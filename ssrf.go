@@ -0,0 +1,99 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("ssrf",
+		"this tests for outbound HTTP requests to URLs built from the incoming request",
+		severityHigh,
+		categorySecurity,
+		ssrfCheck,
+		callExpr)
+}
+
+// requestURLArg returns the URL argument of calls making outbound requests
+// http.NewRequest covers client.Do, the request it sends is made there
+func requestURLArg(f *File, call *ast.CallExpr) ast.Expr {
+	index := -1;
+	if path, name := f.getPkgFunc(call); path == "net/http" {
+		switch name {
+		case "Get", "Head", "Post", "PostForm":
+			index = 0;
+		case "NewRequest":
+			index = 1;
+		case "NewRequestWithContext":
+			index = 2;
+		}
+	}
+	switch f.getMethod(call) {
+	case "(*net/http.Client).Get", "(*net/http.Client).Head", "(*net/http.Client).Post", "(*net/http.Client).PostForm":
+		index = 0;
+	}
+	if index < 0 || index >= len(call.Args) {
+		return nil;
+	}
+	return call.Args[index];
+}
+
+// fromRequestInput checks if x uses an *http.Request, such as r.FormValue("url")
+// or r.URL.Query().Get("u"), or variables assigned from one in the enclosing function
+func fromRequestInput(f *File, x ast.Expr, depth int) bool {
+	if depth > 3 {
+		return false;
+	}
+	fun := f.enclosingFunc();
+	found := false;
+	ast.Inspect(x, func(node ast.Node) bool {
+		id, ok := node.(*ast.Ident);
+		if !ok || found {
+			return !found;
+		}
+		if t := f.pkg.info.TypeOf(id); t != nil && t.String() == "*net/http.Request" {
+			found = true;
+			return false;
+		}
+		obj := f.pkg.info.Uses[id];
+		if obj == nil || fun == nil {
+			return true;
+		}
+		ast.Inspect(fun, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt);
+			if !ok || found {
+				return !found;
+			}
+			for i, lhs := range assign.Lhs {
+				if !refersTo(f, lhs, obj) {
+					continue;
+				}
+				rhs := assign.Rhs[0];
+				if len(assign.Rhs) == len(assign.Lhs) {
+					rhs = assign.Rhs[i];
+				}
+				if fromRequestInput(f, rhs, depth+1) {
+					found = true;
+				}
+			}
+			return true;
+		});
+		return !found;
+	})
+	return found;
+}
+
+func ssrfCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	url := requestURLArg(f, call);
+	if url == nil || isConst(f, url) || !fromRequestInput(f, url, 0) {
+		return;
+	}
+	f.Reportf(call.Pos(), "outbound request to a URL from the incoming request, check it against an allowlist: %s", f.ASTString(call));
+	return;
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/build"
+	"go/token"
+	"go/types"
+)
+
+var maxStackAlloc = flag.Int64("max-stack-alloc", 64<<10, "largest local array in bytes before stackAlloc reports it")
+
+func init() {
+	register("stackAlloc",
+		"this tests for large arrays declared as local variables",
+		severityLow,
+		categoryPerformance,
+		stackAllocCheck,
+		compositeLit, genDecl)
+}
+
+// archSizes computes type sizes for the architecture being built for
+var archSizes = types.SizesFor("gc", build.Default.GOARCH)
+
+// arraySize returns the size in bytes of t if it is an array, or 0
+func arraySize(t types.Type) int64 {
+	if t == nil || archSizes == nil {
+		return 0;
+	}
+	if _, ok := t.Underlying().(*types.Array); !ok {
+		return 0;
+	}
+	return archSizes.Sizeof(t);
+}
+
+func stackAllocCheck(f *File, node ast.Node) {
+	if f.enclosingFunc() == nil {
+		return;
+	}
+	switch n := node.(type) {
+	case *ast.GenDecl:
+		if n.Tok != token.VAR {
+			return;
+		}
+		for _, spec := range n.Specs {
+			vspec, ok := spec.(*ast.ValueSpec);
+			if !ok {
+				continue;
+			}
+			for _, name := range vspec.Names {
+				if size := arraySize(f.pkg.info.TypeOf(name)); size > *maxStackAlloc {
+					f.Reportf(name.Pos(), "local array %s is %d bytes, more than %d on the stack, allocate it with make or reuse a buffer", name.Name, size, *maxStackAlloc);
+				}
+			}
+		}
+	case *ast.CompositeLit:
+		// elements of a larger literal are counted with it
+		if _, ok := f.parent().(*ast.CompositeLit); ok {
+			return;
+		}
+		// var x = [N]T{} is reported with the declaration
+		if _, ok := f.parent().(*ast.ValueSpec); ok {
+			return;
+		}
+		if size := arraySize(f.pkg.info.TypeOf(n)); size > *maxStackAlloc {
+			f.Reportf(n.Pos(), "local array literal %s is %d bytes, more than %d on the stack, allocate it with make or reuse a buffer", f.ASTString(n.Type), size, *maxStackAlloc);
+		}
+	}
+	return;
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("staticSalt",
+		"this tests for constant or all zero salts passed to password hashing functions",
+		severityMedium,
+		categorySecurity,
+		staticSaltCheck,
+		callExpr)
+}
+
+// saltArg returns the salt argument of key derivation functions
+func saltArg(f *File, call *ast.CallExpr) ast.Expr {
+	path, name := f.getPkgFunc(call);
+	if len(call.Args) < 2 {
+		return nil;
+	}
+	switch path {
+	case "golang.org/x/crypto/pbkdf2", "golang.org/x/crypto/scrypt":
+		if name == "Key" {
+			return call.Args[1];
+		}
+	case "golang.org/x/crypto/argon2":
+		if name == "Key" || name == "IDKey" {
+			return call.Args[1];
+		}
+	}
+	return nil;
+}
+
+// zeroBytes checks for a byte slice that can only be zeros,
+// make([]byte, n) or a literal like []byte{0, 0, 0, 0}
+func zeroBytes(f *File, x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.CallExpr:
+		return isBuiltin(f, x, "make");
+	case *ast.CompositeLit:
+		for _, elt := range x.Elts {
+			if !isZero(f, elt) {
+				return false;
+			}
+		}
+		return true;
+	}
+	return false;
+}
+
+// constBytes checks for a byte slice literal of constants like []byte{1, 2, 3}
+func constBytes(f *File, x ast.Expr) bool {
+	lit, ok := x.(*ast.CompositeLit);
+	if !ok || len(lit.Elts) == 0 {
+		return false;
+	}
+	for _, elt := range lit.Elts {
+		if !isConst(f, elt) {
+			return false;
+		}
+	}
+	return true;
+}
+
+func staticSaltCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	salt := saltArg(f, call);
+	if salt == nil {
+		return;
+	}
+	if zeroBytes(f, salt) {
+		f.Reportf(salt.Pos(), "salt is all zeros, use a random salt per password: %s", f.ASTString(salt));
+		return;
+	}
+	if _, ok := literalKey(f, salt); ok || constBytes(f, salt) {
+		f.Reportf(salt.Pos(), "hardcoded salt, use a random salt per password: %s", f.ASTString(salt));
+	}
+	return;
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+var showStats = flag.Bool("stats", false, "print how long each checker ran and how many findings it made")
+
+// checkerStat is the time spent in one checker over the run
+type checkerStat struct {
+	calls	int
+	total	time.Duration
+}
+
+var (
+	stats	= make(map[string]*checkerStat)
+	statsMu	sync.Mutex
+)
+
+// recordStat adds one call of a checker to its stats
+func recordStat(name string, elapsed time.Duration) {
+	statsMu.Lock();
+	defer statsMu.Unlock();
+	s, ok := stats[name];
+	if !ok {
+		s = new(checkerStat);
+		stats[name] = s;
+	}
+	s.calls++;
+	s.total += elapsed;
+}
+
+// writeStats prints a table of checkers, slowest first
+// packages reused from -cache are not counted since no checker ran on them
+func writeStats(w io.Writer, findings []Finding) error {
+	counts := make(map[string]int);
+	for _, finding := range findings {
+		counts[finding.Checker]++;
+	}
+	statsMu.Lock();
+	defer statsMu.Unlock();
+	var names []string;
+	for name := range stats {
+		names = append(names, name);
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := stats[names[i]], stats[names[j]];
+		if a.total != b.total {
+			return a.total > b.total;
+		}
+		return names[i] < names[j];
+	})
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0);
+	fmt.Fprintf(tw, "checker\tcalls\ttotal ms\tfindings\n");
+	for _, name := range names {
+		s := stats[name];
+		fmt.Fprintf(tw, "%s\t%d\t%.3f\t%d\n", name, s.calls, float64(s.total)/float64(time.Millisecond), counts[name]);
+	}
+	return tw.Flush();
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// registerTest registers a checker for the rest of a test
+func registerTest(t *testing.T, name string, fn func(*File, ast.Node), types ...ast.Node) {
+	register(name, "a checker for tests", severityLow, categoryCorrectness, fn, types...);
+	t.Cleanup(func() {
+		unregister(name);
+	});
+}
+
+// TestStats checks a slow checker shows up in -stats with its time and counts
+func TestStats(t *testing.T) {
+	const delay = 2 * time.Millisecond;
+	registerTest(t, "slowDummy", func(f *File, node ast.Node) {
+		time.Sleep(delay);
+		f.Reportf(node.Pos(), "slow");
+	}, funcDecl);
+	name := filepath.Join(t.TempDir(), "slow.go");
+	if err := os.WriteFile(name, []byte("package p\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	saved := stats;
+	stats = make(map[string]*checkerStat);
+	defer func() {
+		stats = saved;
+	}();
+	setFlag(t, "stats", "true");
+	found := runCheckers(t, "slowDummy", name);
+	s := stats["slowDummy"];
+	if s == nil {
+		t.Fatal("no stats for slowDummy");
+	}
+	if s.calls != 3 || s.total < 3*delay {
+		t.Errorf("got %d calls taking %s, want 3 taking at least %s", s.calls, s.total, 3*delay);
+	}
+	var b bytes.Buffer;
+	if err := writeStats(&b, found); err != nil {
+		t.Fatal(err);
+	}
+	lines := strings.Split(b.String(), "\n");
+	if len(lines) < 2 {
+		t.Fatalf("no rows in:\n%s", b.String());
+	}
+	// checker, calls, total ms and findings
+	row := strings.Fields(lines[1]);
+	if len(row) != 4 || row[0] != "slowDummy" || row[1] != "3" || row[2] == "0.000" || row[3] != "3" {
+		t.Errorf("got row %q, want slowDummy with 3 calls, a time and 3 findings", lines[1]);
+	}
+}
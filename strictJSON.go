@@ -0,0 +1,79 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+)
+
+var strictJSON = flag.Bool("strict-json", false, "report JSON decoded into structs without DisallowUnknownFields")
+
+func init() {
+	registerOptIn("strictJSON",
+		"this tests for JSON decoded into structs without rejecting unknown fields",
+		severityLow,
+		categoryCorrectness,
+		func() bool {
+			return *strictJSON;
+		},
+		strictJSONCheck,
+		callExpr)
+}
+
+// structTarget checks if a decode target is a pointer to a struct
+func structTarget(f *File, x ast.Expr) bool {
+	t := f.pkg.info.TypeOf(x);
+	if t == nil {
+		return false;
+	}
+	ptr, ok := t.Underlying().(*types.Pointer);
+	if !ok {
+		return false;
+	}
+	_, ok = ptr.Elem().Underlying().(*types.Struct);
+	return ok;
+}
+
+// disallowsUnknown looks for dec.DisallowUnknownFields() before the decode in body
+func disallowsUnknown(f *File, body ast.Node, dec *ast.Ident, decode *ast.CallExpr) bool {
+	obj := f.pkg.info.ObjectOf(dec);
+	found := false;
+	ast.Inspect(body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr);
+		if !ok || found || call.Pos() >= decode.Pos() {
+			return !found;
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "DisallowUnknownFields" && refersTo(f, sel.X, obj) {
+			found = true;
+		}
+		return !found;
+	})
+	return found;
+}
+
+func strictJSONCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	if path, name := f.getPkgFunc(call); path == "encoding/json" && name == "Unmarshal" && len(call.Args) == 2 {
+		if structTarget(f, call.Args[1]) {
+			f.Reportf(call.Pos(), "json.Unmarshal ignores unknown fields, use a json.Decoder with DisallowUnknownFields: %s", f.ASTString(call));
+		}
+		return;
+	}
+	if f.getMethod(call) != "(*encoding/json.Decoder).Decode" || len(call.Args) != 1 || !structTarget(f, call.Args[0]) {
+		return;
+	}
+	// json.NewDecoder(r).Decode(&v) has no decoder to configure
+	if dec, ok := call.Fun.(*ast.SelectorExpr).X.(*ast.Ident); ok {
+		if fun := f.enclosingFunc(); fun != nil && disallowsUnknown(f, fun, dec, call) {
+			return;
+		}
+	}
+	f.Reportf(call.Pos(), "JSON decoded without DisallowUnknownFields, unknown fields are silently ignored: %s", f.ASTString(call));
+	return;
+}
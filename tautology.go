@@ -0,0 +1,119 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("selfCompare",
+		"this tests for expressions compared with themselves which are always true or false",
+		severityLow,
+		categoryCorrectness,
+		selfCompareCheck,
+		binaryExpr)
+	register("unsignedCompare",
+		"this tests for unsigned values compared with 0 in ways that are always true or false",
+		severityMedium,
+		categoryCorrectness,
+		unsignedCompareCheck,
+		binaryExpr)
+}
+
+// pureExpr checks that evaluating x twice gives the same value,
+// there are no function calls or channel receives in it
+func pureExpr(f *File, x ast.Expr) bool {
+	pure := true;
+	ast.Inspect(x, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			// conversions are fine
+			if !f.pkg.info.Types[n.Fun].IsType() {
+				pure = false;
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				pure = false;
+			}
+		}
+		return pure;
+	})
+	return pure;
+}
+
+// negates checks if y is !x
+func negates(f *File, x, y ast.Expr) bool {
+	not, ok := ast.Unparen(y).(*ast.UnaryExpr);
+	return ok && not.Op == token.NOT && f.ASTString(ast.Unparen(not.X)) == f.ASTString(ast.Unparen(x));
+}
+
+func selfCompareCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok || !pureExpr(f, expr) {
+		return;
+	}
+	switch expr.Op {
+	case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+		if f.ASTString(expr.X) != f.ASTString(expr.Y) {
+			return;
+		}
+		// x != x is the usual NaN test
+		if isFloat(f.pkg.info.TypeOf(expr.X)) {
+			return;
+		}
+		f.Reportf(expr.Pos(), "%s compares an expression with itself and is always %t", f.ASTString(expr), expr.Op == token.EQL || expr.Op == token.LEQ || expr.Op == token.GEQ);
+	case token.LAND, token.LOR:
+		if !negates(f, expr.X, expr.Y) && !negates(f, expr.Y, expr.X) {
+			return;
+		}
+		f.Reportf(expr.Pos(), "%s is always %t", f.ASTString(expr), expr.Op == token.LOR);
+	}
+	return;
+}
+
+// isUnsigned checks if t is an unsigned integer type
+func isUnsigned(t types.Type) bool {
+	if t == nil {
+		return false;
+	}
+	basic, ok := t.Underlying().(*types.Basic);
+	return ok && basic.Info()&types.IsUnsigned != 0;
+}
+
+func unsignedCompareCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok {
+		return;
+	}
+	var always bool;
+	switch {
+	case isUnsigned(f.pkg.info.TypeOf(expr.X)) && isZero(f, expr.Y):
+		// n < 0 or n >= 0
+		switch expr.Op {
+		case token.LSS:
+			always = false;
+		case token.GEQ:
+			always = true;
+		default:
+			return;
+		}
+	case isUnsigned(f.pkg.info.TypeOf(expr.Y)) && isZero(f, expr.X):
+		// 0 > n or 0 <= n
+		switch expr.Op {
+		case token.GTR:
+			always = false;
+		case token.LEQ:
+			always = true;
+		default:
+			return;
+		}
+	default:
+		return;
+	}
+	f.Reportf(expr.Pos(), "unsigned comparison %s is always %t", f.ASTString(expr), always);
+	return;
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+func init() {
+	register("testEnv",
+		"this tests for os.Setenv in tests without restoring the environment",
+		severityLow,
+		categoryCorrectness,
+		testEnvCheck,
+		callExpr)
+}
+
+// isSetenv checks for calls changing the environment
+func isSetenv(f *File, call *ast.CallExpr) bool {
+	path, name := f.getPkgFunc(call);
+	return path == "os" && (name == "Setenv" || name == "Unsetenv");
+}
+
+// restoresEnv checks a test function for t.Cleanup or a deferred os.Setenv
+func restoresEnv(f *File, body *ast.BlockStmt) bool {
+	found := false;
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.DeferStmt:
+			if isSetenv(f, n.Call) {
+				found = true;
+			}
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Cleanup" {
+				found = true;
+			}
+		}
+		return !found;
+	})
+	return found;
+}
+
+func testEnvCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || !strings.HasSuffix(f.name, "_test.go") || !isSetenv(f, call) {
+		return;
+	}
+	// the deferred call is the cleanup
+	if _, ok := f.parent().(*ast.DeferStmt); ok {
+		return;
+	}
+	fun := f.enclosingFuncDecl();
+	if fun == nil || restoresEnv(f, fun.Body) {
+		return;
+	}
+	f.Reportf(call.Pos(), "environment changed without cleanup, use t.Setenv: %s", f.ASTString(call));
+	return;
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// testing methods that stop the calling goroutine, which must be the test's own
+var goexitMethods = []string{"Fatal", "Fatalf", "FailNow", "Skip", "Skipf", "SkipNow"}
+
+func init() {
+	register("testGoFatal",
+		"this tests for t.Fatal and t.FailNow called from goroutines started by a test",
+		severityMedium,
+		categoryCorrectness,
+		testGoFatalCheck,
+		goStmt)
+}
+
+// stopsTest checks for t.Fatal and the like on a *testing.T, *testing.B or testing.TB
+func stopsTest(f *File, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok {
+		return false;
+	}
+	t := f.pkg.info.TypeOf(sel.X);
+	if t == nil {
+		return false;
+	}
+	switch t.String() {
+	case "*testing.T", "*testing.B", "testing.TB":
+	default:
+		return false;
+	}
+	for _, method := range goexitMethods {
+		if sel.Sel.Name == method {
+			return true;
+		}
+	}
+	return false;
+}
+
+func testGoFatalCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.GoStmt);
+	if !ok || !strings.HasSuffix(f.name, "_test.go") {
+		return;
+	}
+	lit, ok := stmt.Call.Fun.(*ast.FuncLit);
+	if !ok {
+		return;
+	}
+	ast.Inspect(lit.Body, func(node ast.Node) bool {
+		// goroutines started inside are checked on their own
+		if _, ok := node.(*ast.GoStmt); ok {
+			return false;
+		}
+		call, ok := node.(*ast.CallExpr);
+		if ok && stopsTest(f, call) {
+			f.Reportf(call.Pos(), "%s called from a goroutine does not stop the test, use t.Error and return or send the error back: %s", call.Fun.(*ast.SelectorExpr).Sel.Name, f.ASTString(call));
+		}
+		return true;
+	})
+	return;
+}
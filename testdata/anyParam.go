@@ -0,0 +1,22 @@
+package main
+
+import(
+	"fmt"
+)
+
+// bad
+func Store(key string, value interface{}) {
+}
+
+// bad
+func StoreAll(values ...any) {
+}
+
+// good, logging wrapper
+func Logf(format string, args ...interface{}) {
+	fmt.Printf(format, args...)
+}
+
+// good
+func StoreString(key, value string) {
+}
@@ -0,0 +1,20 @@
+package main
+
+func appendParam(names []string) []string {
+	// bad
+	names = append(names, "root")
+
+	// good
+	local := make([]string, 0, len(names))
+	local = append(local, names...)
+	return local
+}
+
+// appendTo appends to dst and returns the extended slice
+func appendTo(dst []byte, s string) []byte {
+	// good, documented
+	dst = append(dst, s...)
+
+	// good
+	return append(dst, '\n')
+}
@@ -0,0 +1,20 @@
+package main
+
+import(
+	"fmt"
+)
+
+type assertCallCloser interface {
+	Close() error
+}
+
+func assertCall(v interface{}) error {
+	// bad
+	v.(assertCallCloser).Close()
+
+	// good
+	if c, ok := v.(assertCallCloser); ok && c != nil {
+		return c.Close()
+	}
+	return fmt.Errorf("%v cannot be closed", v)
+}
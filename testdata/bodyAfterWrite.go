@@ -0,0 +1,24 @@
+package main
+
+import(
+	"io"
+	"net/http"
+)
+
+func bodyAfterWrite(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	w.WriteHeader(http.StatusAccepted)
+	// bad
+	io.Copy(io.Discard, r.Body)
+}
+
+func bodyBeforeWrite(w http.ResponseWriter, r *http.Request) {
+	// good
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Write(body)
+	r.Body.Close()
+}
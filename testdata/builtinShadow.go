@@ -0,0 +1,24 @@
+package main
+
+func builtinShadow(items []string) int {
+	// bad
+	type error struct{}
+
+	// bad
+	len := 0
+	for range items {
+		len++
+	}
+
+	// bad
+	var copy []string
+
+	// good
+	count := len
+	return count + cap(copy)
+}
+
+// bad
+func builtinShadowParam(new string) string {
+	return new
+}
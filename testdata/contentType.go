@@ -0,0 +1,34 @@
+package main
+
+import(
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// bad
+func contentTypeMissing(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "hello %s", r.URL.Query().Get("name"))
+}
+
+// bad
+func contentTypeLate(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	w.Header().Set("Content-Type", "application/json")
+}
+
+// good
+func contentTypeSet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// good, http.Error sets its own
+func contentTypeError(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "not found", http.StatusNotFound)
+}
+
+// good, no body is written
+func contentTypeNoBody(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package main
+
+import(
+	"sync"
+)
+
+type copyLockCounter struct {
+	mu sync.Mutex
+	n  int
+}
+
+// bad
+func (c copyLockCounter) Value() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// bad
+func copyLockByValue(c copyLockCounter) {
+}
+
+// good
+func (c *copyLockCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func copyLock(c *copyLockCounter) copyLockCounter {
+	// bad
+	snapshot := *c
+	copyLockByValue(snapshot)
+
+	// good
+	fresh := copyLockCounter{}
+	copyLockPtr(&fresh)
+
+	// bad
+	return *c
+}
+
+// good
+func copyLockPtr(c *copyLockCounter) {
+}
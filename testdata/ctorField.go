@@ -0,0 +1,14 @@
+package main
+
+import(
+	"net/http"
+	"time"
+)
+
+func ctorField(req *http.Request, srv *http.Server) {
+	// bad, http.NewRequest exists
+	req.Method = "DELETE"
+
+	// good, there is no http.NewServer
+	srv.ReadTimeout = time.Second
+}
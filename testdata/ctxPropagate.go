@@ -0,0 +1,29 @@
+package main
+
+import(
+	"context"
+	"database/sql"
+	"os/exec"
+)
+
+func ctxPropagate(ctx context.Context, db *sql.DB) error {
+	// bad
+	rows, err := db.Query("SELECT 1")
+	if err != nil {
+		return err
+	}
+	rows.Close()
+
+	// bad
+	exec.Command("true").Run()
+
+	// good
+	_, err = db.ExecContext(ctx, "SELECT 1")
+	return err
+}
+
+func ctxNone(db *sql.DB) error {
+	// good, there is no context to pass
+	_, err := db.Exec("SELECT 1")
+	return err
+}
@@ -0,0 +1,8 @@
+package main
+
+import(
+	// bad
+	_ "net/http/pprof"
+	// bad
+	_ "expvar"
+)
@@ -0,0 +1,23 @@
+package main
+
+import(
+	"net/http"
+)
+
+func muxHandler(w http.ResponseWriter, r *http.Request) {
+}
+
+func explicitMux() error {
+	// good
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", muxHandler)
+	return http.ListenAndServe(":8080", mux)
+}
+
+func defaultMux() error {
+	// bad
+	http.Handle("/debug", http.HandlerFunc(muxHandler))
+
+	// bad
+	return http.ListenAndServeTLS(":8443", "cert.pem", "key.pem", nil)
+}
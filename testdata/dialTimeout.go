@@ -0,0 +1,17 @@
+package main
+
+import(
+	"net"
+	"time"
+)
+
+func dialTimeout(addr string) (net.Conn, error) {
+	// bad
+	conn, err := net.Dial("tcp", addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	// good
+	return net.DialTimeout("tcp", addr, 5*time.Second)
+}
@@ -0,0 +1,14 @@
+package main
+
+func doubleClose(done chan struct{}, results chan int) {
+	close(results)
+	if len(done) > 0 {
+		close(done)
+		return
+	}
+	// good, the other close returns first
+	close(done)
+
+	// bad
+	close(results)
+}
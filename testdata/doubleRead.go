@@ -0,0 +1,20 @@
+package main
+
+import(
+	"os"
+)
+
+func doubleRead() (int, error) {
+	data, err := os.ReadFile("config.json")
+	if err != nil {
+		return 0, err
+	}
+	// good, a different file
+	extra, err := os.ReadFile("extra.json")
+	if err != nil {
+		return 0, err
+	}
+	// bad
+	again, err := os.ReadFile("config.json")
+	return len(data) + len(extra) + len(again), err
+}
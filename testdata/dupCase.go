@@ -0,0 +1,30 @@
+package main
+
+const dupCaseAdmin = "admin"
+
+func dupCase(role string, n int) int {
+	// bad
+	switch role {
+	case "admin":
+		return 1
+	case "user", dupCaseAdmin:
+		return 2
+	}
+
+	// bad
+	switch {
+	case n > 10:
+		return 3
+	case n > 10:
+		return 4
+	}
+
+	// good
+	switch n {
+	case 1:
+		return 5
+	case 2, 3:
+		return 6
+	}
+	return 0
+}
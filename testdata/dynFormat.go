@@ -0,0 +1,21 @@
+package main
+
+import(
+	"fmt"
+	"net/http"
+)
+
+const dynGreeting = "hello %s\n"
+
+func dynFormat(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	// bad
+	fmt.Fprintf(w, name)
+
+	// good
+	fmt.Fprintf(w, "%s", name)
+
+	// good
+	fmt.Fprintf(w, dynGreeting, name)
+}
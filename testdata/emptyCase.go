@@ -0,0 +1,27 @@
+package main
+
+import(
+	"fmt"
+)
+
+func emptyCase(v interface{}) string {
+	switch v.(type) {
+	// bad
+	case int:
+	case int64:
+		return "integer"
+	}
+
+	switch x := v.(type) {
+	// bad
+	case string, []byte:
+		// TODO
+	// good
+	case nil:
+		// nothing to describe
+	case fmt.Stringer:
+		return x.String()
+	default:
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package main
+
+import(
+	"os"
+	"strconv"
+)
+
+func emptyErr() int {
+	// bad
+	n, err := strconv.Atoi("10")
+	if err != nil {
+	}
+
+	// bad
+	_, err = os.Stat("emptyErr.go")
+	if err != nil {
+		// TODO
+	}
+
+	// good
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,36 @@
+package main
+
+import(
+	"os"
+)
+
+func errOverwrite(name string) error {
+	// bad
+	err := os.Mkdir(name, 0700)
+	err = os.Chdir(name)
+	if err != nil {
+		return err
+	}
+
+	// good, checked in between
+	err = os.Mkdir(name+"/a", 0700)
+	if err != nil {
+		return err
+	}
+	err = os.Mkdir(name+"/b", 0700)
+	if err != nil {
+		return err
+	}
+
+	// good, passed on to the next call
+	file, err := os.Create(name + "/c")
+	err = errOverwriteClose(file, err)
+	return err
+}
+
+func errOverwriteClose(file *os.File, err error) error {
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
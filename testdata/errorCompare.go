@@ -0,0 +1,21 @@
+package main
+
+import(
+	"errors"
+	"io"
+)
+
+func errorCompare(err error) bool {
+	// bad
+	if err.Error() == "unexpected EOF" {
+		return true
+	}
+
+	// bad
+	if "not found" != err.Error() {
+		return false
+	}
+
+	// good
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
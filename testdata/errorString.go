@@ -0,0 +1,23 @@
+package main
+
+import(
+	"errors"
+	"fmt"
+)
+
+func errorString(name string) []error {
+	return []error{
+		// bad
+		errors.New("Something went wrong"),
+
+		// bad
+		fmt.Errorf("cannot open %s.", name),
+
+		// good
+		errors.New("something went wrong"),
+
+		// good, acronyms and identifiers
+		fmt.Errorf("HTTP request for %s failed", name),
+		errors.New("ReadFile failed"),
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import(
+	"log"
+	"os"
+)
+
+func init() {
+	// good
+	if len(os.Args) > 10 {
+		os.Exit(2)
+	}
+}
+
+func exitHelper(err error) {
+	if err != nil {
+		// bad
+		log.Fatalf("error: %v", err)
+	}
+	// bad
+	os.Exit(0)
+}
+
+func exitDefer() {
+	f, err := os.Create("exit.txt")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	// bad
+	os.Exit(1)
+}
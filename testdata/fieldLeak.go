@@ -0,0 +1,27 @@
+package main
+
+type FieldLeakRegistry struct {
+	items []string
+	byName map[string]int
+	name string
+}
+
+// bad
+func (r *FieldLeakRegistry) Items() []string {
+	return r.items
+}
+
+// good
+func (r *FieldLeakRegistry) ItemsCopy() []string {
+	return append([]string(nil), r.items...)
+}
+
+// good, strings are immutable
+func (r *FieldLeakRegistry) Name() string {
+	return r.name
+}
+
+// good, not exported
+func (r *FieldLeakRegistry) names() map[string]int {
+	return r.byName
+}
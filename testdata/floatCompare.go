@@ -0,0 +1,14 @@
+package main
+
+func floatCompare(a, b float64, n, m int) bool {
+	// bad
+	if a+b == 0.3 {
+		return true
+	}
+	// bad, but often intended
+	if b != 0.0 {
+		a = a / b
+	}
+	// good
+	return n == m
+}
@@ -0,0 +1,19 @@
+package main
+
+import(
+	"os"
+)
+
+// bad
+var globalEnvAddr = os.Getenv("LISTEN_ADDR")
+
+// good
+var globalEnvDefault = ":8080"
+
+func globalEnv() string {
+	// good
+	if addr := os.Getenv("LISTEN_ADDR"); addr != "" {
+		return addr
+	}
+	return globalEnvDefault
+}
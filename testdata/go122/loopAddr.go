@@ -0,0 +1,14 @@
+package go122
+
+type loopItem struct {
+	name string
+}
+
+func loopAddr(items []loopItem) []*loopItem {
+	var ptrs []*loopItem
+	for _, item := range items {
+		// good, from Go 1.22 each iteration has its own item
+		ptrs = append(ptrs, &item)
+	}
+	return ptrs
+}
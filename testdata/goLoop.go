@@ -0,0 +1,63 @@
+package main
+
+import(
+	"sync"
+)
+
+func goLoopProcess(s string) {
+}
+
+func goLoopUnbounded(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		// bad
+		go func(item string) {
+			defer wg.Done()
+			goLoopProcess(item)
+		}(item)
+	}
+	wg.Wait()
+}
+
+func goLoopPool(items []string) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		// good
+		go func(item string) {
+			defer func() { <-sem; wg.Done() }()
+			goLoopProcess(item)
+		}(item)
+	}
+	wg.Wait()
+}
+
+func goLoopFixed() {
+	for i := 0; i < 4; i++ {
+		// good
+		go goLoopProcess("worker")
+	}
+}
+
+func goLoopWorkers(jobs chan string, workers int) {
+	for i := 0; i < workers; i++ {
+		// good, a worker pool sized by a variable
+		go goLoopWorker(jobs)
+	}
+}
+
+func goLoopWorker(jobs chan string) {
+	for job := range jobs {
+		goLoopProcess(job)
+	}
+}
+
+func goLoopIndex(items []string) {
+	for i := 0; i < len(items); i++ {
+		// bad
+		go goLoopProcess(items[i])
+	}
+}
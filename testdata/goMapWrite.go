@@ -0,0 +1,47 @@
+package main
+
+import(
+	"sync"
+)
+
+func goMapWrite(keys []string) {
+	// bad
+	counts := make(map[string]int)
+	go func() {
+		counts["go"]++
+	}()
+	counts["main"]++
+
+	// bad
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		go func(k string) {
+			seen[k] = true
+		}(k)
+	}
+
+	// good
+	var mu sync.Mutex
+	locked := make(map[string]int)
+	for _, k := range keys {
+		go func(k string) {
+			mu.Lock()
+			locked[k]++
+			mu.Unlock()
+		}(k)
+	}
+
+	// good
+	var safe sync.Map
+	for _, k := range keys {
+		go func(k string) {
+			safe.Store(k, true)
+		}(k)
+	}
+
+	// good, only the goroutine writes
+	owned := make(map[string]int)
+	go func() {
+		owned["go"]++
+	}()
+}
@@ -0,0 +1,20 @@
+package main
+
+import(
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func grpcInsecure(addr string) {
+	// bad
+	grpc.Dial(addr, grpc.WithInsecure())
+
+	// bad
+	grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	// good
+	grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+}
@@ -0,0 +1,20 @@
+package main
+
+import(
+	"net/http"
+)
+
+func headerForward(w http.ResponseWriter, r *http.Request) {
+	out, _ := http.NewRequest(http.MethodGet, "https://backend.internal/api", nil)
+
+	// bad
+	out.Header.Set("Authorization", r.Header.Get("Authorization"))
+
+	// bad
+	out.Header.Add("cookie", r.Header.Get("Cookie"))
+
+	// good
+	out.Header.Set("X-Request-Id", r.Header.Get("X-Request-Id"))
+
+	http.DefaultClient.Do(out)
+}
@@ -0,0 +1,21 @@
+package main
+
+import(
+	"time"
+)
+
+func initWorker() {
+	for {
+		time.Sleep(time.Second)
+	}
+}
+
+func init() {
+	// bad
+	go initWorker()
+}
+
+func startWorker() {
+	// good
+	go initWorker()
+}
@@ -0,0 +1,22 @@
+package main
+
+import(
+	"io"
+)
+
+// bad
+type bigStore interface {
+	io.Closer
+	Get(key string) string
+	Put(key, value string)
+	Delete(key string)
+	Keys() []string
+	Len() int
+	Flush() error
+}
+
+// good
+type smallStore interface {
+	io.Closer
+	Get(key string) string
+}
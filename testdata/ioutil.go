@@ -0,0 +1,20 @@
+package main
+
+import(
+	"io/ioutil"
+	"os"
+)
+
+func ioutilDeprecated(name string) ([]byte, error) {
+	// bad
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// good
+	if err := os.WriteFile(name+".bak", data, 0600); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
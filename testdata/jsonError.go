@@ -0,0 +1,25 @@
+package main
+
+import(
+	"encoding/json"
+)
+
+type jsonErrorConfig struct {
+	Name	string
+}
+
+func jsonError(data []byte) ([]byte, error) {
+	var c jsonErrorConfig
+	// bad
+	json.Unmarshal(data, &c)
+
+	// bad
+	out, _ := json.Marshal(c)
+	_ = out
+
+	// good
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return json.Marshal(c)
+}
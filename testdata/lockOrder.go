@@ -0,0 +1,38 @@
+package main
+
+import(
+	"sync"
+)
+
+type lockAccounts struct {
+	from, to sync.Mutex
+	balance int
+}
+
+func (a *lockAccounts) debit() {
+	a.from.Lock()
+	defer a.from.Unlock()
+	a.to.Lock()
+	defer a.to.Unlock()
+	a.balance--
+}
+
+func (a *lockAccounts) credit() {
+	a.to.Lock()
+	defer a.to.Unlock()
+	// bad
+	a.from.Lock()
+	defer a.from.Unlock()
+	a.balance++
+}
+
+func (a *lockAccounts) audit() int {
+	// good, released before taking the next lock
+	a.from.Lock()
+	a.from.Unlock()
+	a.to.Lock()
+	a.to.Unlock()
+	a.from.Lock()
+	defer a.from.Unlock()
+	return a.balance
+}
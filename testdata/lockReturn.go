@@ -0,0 +1,32 @@
+package main
+
+import(
+	"sync"
+)
+
+type lockReturnCache struct {
+	mu    sync.Mutex
+	items map[string]string
+}
+
+func (c *lockReturnCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	v, ok := c.items[key]
+	if !ok {
+		// bad
+		return "", false
+	}
+	c.mu.Unlock()
+	// good
+	return v, true
+}
+
+func (c *lockReturnCache) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		// good
+		return
+	}
+	c.items[key] = value
+}
@@ -0,0 +1,35 @@
+package main
+
+type loopItem struct {
+	name string
+}
+
+func loopAddr(items []loopItem) []*loopItem {
+	var ptrs []*loopItem
+	for _, item := range items {
+		// bad
+		ptrs = append(ptrs, &item)
+	}
+	for i := 0; i < len(items); i++ {
+		if items[i].name == "" {
+			// good, the element not the loop variable
+			return []*loopItem{&items[i]}
+		}
+	}
+	for _, item := range items {
+		// good
+		copied := item
+		ptrs = append(ptrs, &copied)
+	}
+	return ptrs
+}
+
+func loopIndex(items []loopItem) *int {
+	for i := 0; i < len(items); i++ {
+		if items[i].name == "" {
+			// bad
+			return &i
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import(
+	"fmt"
+	"sync"
+)
+
+func loopClosure(names []string) {
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		// bad
+		go func() {
+			defer wg.Done()
+			fmt.Println(i, name)
+		}()
+	}
+
+	for i := 0; i < 3; i++ {
+		// bad
+		defer func() {
+			fmt.Println(i)
+		}()
+	}
+
+	// good, passed as an argument
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			fmt.Println(name)
+		}(name)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+package main
+
+import(
+	"bytes"
+	"io"
+)
+
+func loopConvert(w io.Writer, r io.Reader, names []string, prefix []byte, seen map[string]bool) {
+	for _, name := range names {
+		// bad
+		p := string(prefix)
+		w.Write([]byte(p + name))
+	}
+
+	for i := 0; i < 10; i++ {
+		// bad
+		w.Write([]byte("header"))
+	}
+
+	// good, the operand changes every iteration
+	for _, name := range names {
+		w.Write([]byte(name))
+	}
+
+	// good, buf is filled by Read
+	buf := make([]byte, 64)
+	for {
+		if _, err := r.Read(buf); err != nil {
+			break
+		}
+		s := string(buf)
+		_ = s
+	}
+
+	// good, no copy in comparisons and map keys
+	for range names {
+		if string(prefix) == "x" || seen[string(prefix)] {
+			continue
+		}
+		_ = bytes.ToUpper(prefix)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+func lostAppend(s []int, x int) []int {
+	// bad
+	append(s, x)
+
+	// good
+	s = append(s, x)
+	return s
+}
+
+func lostAppendShadowed(x int) {
+	append := func(s []int, x int) {}
+
+	// good, not the builtin
+	append(nil, x)
+}
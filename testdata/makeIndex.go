@@ -0,0 +1,21 @@
+package main
+
+func makeIndex(values []int) []int {
+	// bad
+	first := make([]int, 0, len(values))
+	first[0] = values[0]
+
+	// bad
+	pair := make([]string, 2)
+	pair[2] = "c"
+
+	// good, within the made length
+	out := make([]int, 3)
+	out[0] = 1
+
+	// good, appended to first
+	grown := make([]int, 0, 4)
+	grown = append(grown, 1)
+	_ = grown[0]
+	return out
+}
@@ -0,0 +1,37 @@
+package main
+
+type mapUser struct {
+	name string
+}
+
+func mapNil(users map[string]*mapUser, hooks map[string]func(), id string) string {
+	// bad
+	hooks[id]()
+
+	// good
+	if u, ok := users[id]; ok {
+		return u.name
+	}
+
+	// bad
+	return users[id].name
+}
+
+func mapNilGuarded(users map[string]*mapUser, hooks map[string]func(), id, other string) string {
+	// good, the lookup is checked first
+	if _, ok := hooks[id]; ok {
+		hooks[id]()
+	}
+
+	// bad, a different key is checked
+	if _, ok := hooks[other]; ok {
+		hooks[id]()
+	}
+
+	// good, the function leaves when the key is missing
+	_, ok := users[id]
+	if !ok {
+		return ""
+	}
+	return users[id].name
+}
@@ -0,0 +1,19 @@
+package main
+
+// bad, the fixture comments are kept apart so they are not doc comments
+
+func MissingDoc() {}
+
+type MissingDocType struct{}
+
+var MissingDocVar = 1
+
+// good
+
+// MissingDocGood says what it does.
+func MissingDocGood() {}
+
+// MissingDocLimit is the most of anything.
+const MissingDocLimit = 10
+
+func missingDoc() {}
@@ -0,0 +1,25 @@
+package main
+
+import(
+	"net/http"
+)
+
+func muxPatterns(api, admin, users http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api)
+	// bad
+	mux.Handle("/api", admin)
+
+	mux.Handle("/admin", admin)
+	// bad
+	mux.Handle("/admin/users", users)
+
+	// good
+	mux.Handle("/static/", api)
+	mux.Handle("/static/css/", api)
+
+	// good, a different mux
+	other := http.NewServeMux()
+	other.Handle("/api", api)
+	other.Handle("/health", api)
+}
@@ -0,0 +1,31 @@
+package main
+
+import(
+	"errors"
+)
+
+// bad
+func parsePort(s string) (int, error) {
+	if s == "" {
+		return 80, nil
+	}
+	return len(s), nil
+}
+
+// good
+func parseHost(s string) (string, error) {
+	if s == "" {
+		return "", errors.New("empty host")
+	}
+	return s, nil
+}
+
+// good, the deferred function sets the named error
+func parseTimeout(s string) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("bad timeout")
+		}
+	}()
+	return len(s), nil
+}
@@ -1,6 +1,8 @@
 package main
 
 import(
+	"bufio"
+	"io"
 	"os"
 )
 
@@ -18,3 +20,56 @@ func noClose() int {
 	}
 	return 0
 }
+
+func fileCloseLeak(name string) (int, error) {
+	// bad
+	file, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, nil
+}
+
+func fileCloseDeferred(name string, w io.Writer) error {
+	// good
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+func fileCloseReturned(name string) (*os.File, error) {
+	// good, the caller closes it
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func fileCloseNamedResult(name string) (file *os.File, err error) {
+	// good, the bare return hands it to the caller
+	file, err = os.Open(name)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func fileCloseNamedDropped(name string) (n int, err error) {
+	// bad, file is not one of the results
+	file, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	n = 1
+	return
+}
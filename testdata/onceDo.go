@@ -0,0 +1,43 @@
+package main
+
+import(
+	"sync"
+)
+
+type onceConfig struct {
+	once	sync.Once
+	values	map[string]string
+}
+
+func (c *onceConfig) load() {
+	c.values = map[string]string{}
+}
+
+func (c *onceConfig) loadDefaults() {
+	c.values = map[string]string{"mode": "default"}
+}
+
+func (c *onceConfig) Get(key string) string {
+	c.once.Do(c.load)
+	return c.values[key]
+}
+
+func (c *onceConfig) Mode() string {
+	// good, the same function
+	c.once.Do(c.load)
+	return c.values["mode"]
+}
+
+func (c *onceConfig) Reset() {
+	// bad
+	c.once.Do(c.loadDefaults)
+}
+
+var setupOnce sync.Once
+
+func onceSetup() {
+	// good, a single call site
+	setupOnce.Do(func() {
+		println("setup")
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import(
+	"fmt"
+	"strconv"
+)
+
+func panicFlowParse(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// bad
+func panicFlow(s string) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("parsing %s: %v", s, r)
+		}
+	}()
+	return panicFlowParse(s), nil
+}
+
+// good
+func panicFlowReturn(s string) (int, error) {
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,26 @@
+package main
+
+func rangeMutate(m map[string]int, other map[string]int) {
+	// bad
+	for k, v := range m {
+		m[k+"-copy"] = v
+	}
+
+	// bad
+	for k := range m {
+		delete(m, k+"-copy")
+	}
+
+	// good, deleting or updating the current key
+	for k, v := range m {
+		if v == 0 {
+			delete(m, k)
+		}
+		m[k] = v + 1
+	}
+
+	// good, a different map
+	for k, v := range m {
+		other[k+"-copy"] = v
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import(
+	"log"
+)
+
+func recoverDropped() {
+	// bad
+	defer func() {
+		recover()
+	}()
+}
+
+func recoverBlank() {
+	// bad
+	defer func() {
+		_ = recover()
+	}()
+}
+
+func recoverLogged() {
+	// good
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("recovered: %v", r)
+		}
+	}()
+}
@@ -0,0 +1,27 @@
+package main
+
+func recvLoop(jobs <-chan int, results chan<- int) {
+	// bad
+	for {
+		job := <-jobs
+		results <- job * 2
+	}
+}
+
+func recvLoopOk(jobs <-chan int, results chan<- int) {
+	// good
+	for {
+		job, ok := <-jobs
+		if !ok {
+			return
+		}
+		results <- job * 2
+	}
+}
+
+func recvLoopRange(jobs <-chan int, results chan<- int) {
+	// good
+	for job := range jobs {
+		results <- job * 2
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import(
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+type reflectUnsafeUser struct {
+	name	string
+	admin	bool
+}
+
+func reflectUnsafe(u *reflectUnsafeUser) {
+	field := reflect.ValueOf(u).Elem().FieldByName("admin")
+	// bad
+	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().SetBool(true)
+
+	// good, reading only
+	fmt.Println(reflect.ValueOf(u).Elem().FieldByName("name").String())
+}
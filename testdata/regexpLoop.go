@@ -0,0 +1,28 @@
+package main
+
+import(
+	"regexp"
+)
+
+var regexpLoopWord = regexp.MustCompile(`^\w+$`)
+
+func regexpLoop(lines []string, pattern string) int {
+	n := 0
+	for _, line := range lines {
+		// bad
+		if regexp.MustCompile(`^\d+$`).MatchString(line) {
+			n++
+		}
+
+		// good
+		if regexpLoopWord.MatchString(line) {
+			n++
+		}
+
+		// good, the pattern changes
+		if re, err := regexp.Compile(pattern + line); err == nil && re.MatchString(line) {
+			n++
+		}
+	}
+	return n
+}
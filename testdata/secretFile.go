@@ -0,0 +1,46 @@
+package main
+
+import(
+	"fmt"
+	"os"
+)
+
+type config struct {
+	APIToken	string
+	Name		string
+}
+
+func secretFile(password string, c config, name string) error {
+	// bad
+	os.WriteFile("password.txt", []byte(password), 0644)
+
+	// bad
+	os.WriteFile("token.txt", []byte(c.APIToken), 0640)
+
+	// bad
+	data := []byte(password)
+	os.WriteFile("data.txt", data, 0666)
+
+	// bad
+	out, err := os.OpenFile("creds.txt", os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	fmt.Fprintln(out, c.APIToken)
+
+	// good
+	os.WriteFile("password.txt", []byte(password), 0600)
+
+	// good
+	os.WriteFile("name.txt", []byte(name), 0644)
+
+	// good
+	log, err := os.OpenFile("app.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+	log.WriteString(c.Name)
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+type selfAssignConfig struct {
+	name, host string
+}
+
+func selfAssign(c *selfAssignConfig, other selfAssignConfig, x int, ch chan int) {
+	// bad
+	c.name = c.name
+
+	// bad
+	x = x
+
+	// good
+	c.host = other.host
+	x = x + 1
+
+	// good, each receive is a new value
+	x = <-ch
+	_ = x
+}
@@ -0,0 +1,25 @@
+package main
+
+import(
+	"os"
+	"strconv"
+)
+
+func shadowErr(s string) error {
+	n, err := strconv.Atoi(s)
+	if n > 0 {
+		// bad
+		_, err := os.Stat(s)
+		if err != nil {
+			n = 0
+		}
+	}
+	if n < 0 {
+		// good
+		_, statErr := os.Stat(s)
+		if statErr != nil {
+			n = 0
+		}
+	}
+	return err
+}
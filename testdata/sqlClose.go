@@ -0,0 +1,42 @@
+package main
+
+import(
+	"database/sql"
+)
+
+type store struct {
+	db *sql.DB
+}
+
+func sqlNoClose() error {
+	// bad
+	db, err := sql.Open("postgres", "dsn")
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("DELETE FROM sessions")
+	return err
+}
+
+func sqlClosed() error {
+	// good
+	db, err := sql.Open("postgres", "dsn")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Ping()
+}
+
+func sqlReturned() (*sql.DB, error) {
+	// good, the caller closes it
+	db, err := sql.Open("postgres", "dsn")
+	return db, err
+}
+
+func sqlStored(s *store) error {
+	// good
+	db, err := sql.Open("postgres", "dsn")
+	s.db = db
+	return err
+}
@@ -0,0 +1,24 @@
+package main
+
+import(
+	"context"
+	"net/http"
+)
+
+func ssrf(w http.ResponseWriter, r *http.Request) {
+	// bad
+	http.Get(r.URL.Query().Get("url"))
+
+	// bad
+	target := "https://" + r.FormValue("host") + "/status"
+	req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, target, nil)
+	http.DefaultClient.Do(req)
+
+	// good
+	http.Get("https://status.example.com/health")
+}
+
+func ssrfBackground(ctx context.Context, host string) {
+	// good, not from a request
+	http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host, nil)
+}
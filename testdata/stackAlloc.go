@@ -0,0 +1,16 @@
+package main
+
+// good, package level
+var stackAllocGlobal [1 << 20]byte
+
+func stackAlloc() int {
+	// bad
+	var buf [1 << 20]byte
+
+	// bad
+	table := [100000]int64{}
+
+	// good
+	var small [512]byte
+	return len(buf) + len(table) + len(small)
+}
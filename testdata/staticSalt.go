@@ -0,0 +1,23 @@
+package main
+
+import(
+	"crypto/rand"
+	"crypto/sha256"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func staticSalt(password []byte) ([]byte, error) {
+	// bad
+	pbkdf2.Key(password, []byte("glasgo-salt"), 600000, 32, sha256.New)
+
+	// bad
+	pbkdf2.Key(password, make([]byte, 16), 600000, 32, sha256.New)
+
+	// good
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return pbkdf2.Key(password, salt, 600000, 32, sha256.New), nil
+}
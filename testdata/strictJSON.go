@@ -0,0 +1,23 @@
+package main
+
+import(
+	"encoding/json"
+	"io"
+)
+
+type strictJSONConfig struct {
+	Addr string `json:"addr"`
+}
+
+func strictJSON(r io.Reader) (strictJSONConfig, error) {
+	var conf strictJSONConfig
+
+	// bad
+	json.NewDecoder(r).Decode(&conf)
+
+	// good
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&conf)
+	return conf, err
+}
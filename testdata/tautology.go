@@ -0,0 +1,26 @@
+package main
+
+func tautology(x, y int, ok bool, n uint, f float64) bool {
+	// bad
+	if x == x {
+		return true
+	}
+
+	// bad
+	if ok || !ok {
+		return true
+	}
+
+	// bad
+	if n < 0 {
+		return false
+	}
+
+	// good
+	if x == y || f != f {
+		return true
+	}
+
+	// good
+	return n > 0
+}
@@ -0,0 +1,22 @@
+package main
+
+import(
+	"os"
+	"testing"
+)
+
+func TestEnvLeak(t *testing.T) {
+	// bad
+	os.Setenv("GLASGO_MODE", "test")
+}
+
+func TestEnvDeferred(t *testing.T) {
+	// good
+	os.Setenv("GLASGO_MODE", "test")
+	defer os.Unsetenv("GLASGO_MODE")
+}
+
+func TestEnvSetenv(t *testing.T) {
+	// good
+	t.Setenv("GLASGO_MODE", "test")
+}
@@ -0,0 +1,32 @@
+package main
+
+import(
+	"sync"
+	"testing"
+)
+
+func TestGoFatal(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// bad
+	go func() {
+		defer wg.Done()
+		if err := testGoFatalWork(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// good
+	go func() {
+		defer wg.Done()
+		if err := testGoFatalWork(); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+}
+
+func testGoFatalWork() error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import(
+	"time"
+)
+
+func timeAfterLoop(events <-chan string, done <-chan struct{}) {
+	// bad
+	for {
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			return
+		}
+	}
+}
+
+func timeAfterLoopTimer(events <-chan string) {
+	// good
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case <-events:
+			timer.Reset(time.Second)
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+func timeAfterOnce(events <-chan string) {
+	// good, not in a loop
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+	}
+}
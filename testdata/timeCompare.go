@@ -0,0 +1,21 @@
+package main
+
+import(
+	"time"
+)
+
+func timeCompare(deadline time.Time) bool {
+	now := time.Now()
+	// bad
+	if now == deadline {
+		return true
+	}
+
+	// bad
+	if deadline != (time.Time{}) {
+		return false
+	}
+
+	// good
+	return now.Equal(deadline) || deadline.IsZero()
+}
@@ -0,0 +1,21 @@
+package main
+
+import(
+	"os"
+)
+
+func tmpPath(data []byte) error {
+	// bad
+	if err := os.WriteFile("/tmp/glasgo.out", data, 0600); err != nil {
+		return err
+	}
+
+	// good
+	tmp, err := os.CreateTemp("", "glasgo")
+	if err != nil {
+		return err
+	}
+	defer tmp.Close()
+	_, err = tmp.Write(data)
+	return err
+}
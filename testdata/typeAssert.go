@@ -0,0 +1,19 @@
+package main
+
+func typeAssert(x interface{}) int {
+	// bad
+	s := x.(string)
+
+	// good
+	n, ok := x.(int)
+	if !ok {
+		return len(s)
+	}
+
+	// good
+	switch v := x.(type) {
+	case int:
+		return v
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+package main
+
+type uncomparableConfig struct {
+	hosts []string
+}
+
+func uncomparable(a, b []byte, m1, m2 map[string]int, c1, c2 uncomparableConfig, x, y string) bool {
+	// bad
+	if a == b {
+		return true
+	}
+
+	// bad
+	if m1 != m2 {
+		return false
+	}
+
+	// bad
+	if c1 == c2 {
+		return true
+	}
+
+	// good
+	return a == nil || m1 != nil || x == y
+}
@@ -0,0 +1,37 @@
+package main
+
+import(
+	"io"
+)
+
+type unexportedClient struct {
+	addr string
+}
+
+func (c *unexportedClient) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+type ExportedClient struct {
+	addr string
+}
+
+// bad
+func NewUnexportedClient(addr string) *unexportedClient {
+	return &unexportedClient{addr: addr}
+}
+
+// good
+func NewExportedClient(addr string) *ExportedClient {
+	return &ExportedClient{addr: addr}
+}
+
+// good
+func NewReader(addr string) io.Reader {
+	return &unexportedClient{addr: addr}
+}
+
+// good, not exported
+func newUnexportedClient(addr string) *unexportedClient {
+	return &unexportedClient{addr: addr}
+}
@@ -0,0 +1,30 @@
+package main
+
+import(
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type untypedDecodeReq struct {
+	Name string `json:"name"`
+}
+
+func untypedDecode(w http.ResponseWriter, r *http.Request) {
+	// bad
+	var v interface{}
+	json.NewDecoder(r.Body).Decode(&v)
+
+	// bad
+	data, _ := io.ReadAll(r.Body)
+	var m map[string]interface{}
+	json.Unmarshal(data, &m)
+
+	// good
+	var req untypedDecodeReq
+	json.Unmarshal(data, &req)
+
+	// good, not request data
+	var conf interface{}
+	json.Unmarshal([]byte(`{"debug": true}`), &conf)
+}
@@ -0,0 +1,28 @@
+package main
+
+import(
+	"sync"
+)
+
+func waitGroupAdd(jobs []func()) {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		go func(job func()) {
+			// bad
+			wg.Add(1)
+			defer wg.Done()
+			job()
+		}(job)
+	}
+	wg.Wait()
+
+	for _, job := range jobs {
+		// good
+		wg.Add(1)
+		go func(job func()) {
+			defer wg.Done()
+			job()
+		}(job)
+	}
+	wg.Wait()
+}
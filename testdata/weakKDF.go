@@ -0,0 +1,24 @@
+package main
+
+import(
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+func weakKDF(password, salt []byte) {
+	// bad
+	bcrypt.GenerateFromPassword(password, 4)
+
+	// good
+	bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
+
+	// bad
+	scrypt.Key(password, salt, 1024, 8, 1, 32)
+
+	// bad
+	argon2.IDKey(password, salt, 1, 1024, 4, 32)
+
+	// good
+	argon2.IDKey(password, salt, 2, 64*1024, 4, 32)
+}
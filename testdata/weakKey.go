@@ -0,0 +1,20 @@
+package main
+
+import(
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"os"
+)
+
+func weakKey() (hash.Hash, hash.Hash, hash.Hash) {
+	// bad
+	a := hmac.New(sha256.New, []byte("secret"))
+
+	// bad
+	b := hmac.New(sha256.New, []byte("not-long-enough-key"))
+
+	// good
+	c := hmac.New(sha256.New, []byte(os.Getenv("SIGNING_KEY")))
+	return a, b, c
+}
@@ -0,0 +1,30 @@
+package main
+
+import(
+	"fmt"
+	"os"
+)
+
+// bad
+func WrapErrMissing(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// good
+func WrapErrWrapped(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// good, not exported
+func wrapErrUnexported(name string) error {
+	_, err := os.Stat(name)
+	return err
+}
@@ -0,0 +1,21 @@
+package main
+
+import(
+	"bytes"
+	"net"
+)
+
+func writeCheck(conn net.Conn, msg []byte) error {
+	// bad
+	conn.Write(msg)
+
+	// good
+	var buf bytes.Buffer
+	buf.Write(msg)
+
+	// good
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
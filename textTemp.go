@@ -10,6 +10,8 @@ import (
 func init() {
 	register("textTemp",
 		"this is a test to see if template/text and http methods are in use",
+		severityMedium,
+		categorySecurity,
 		textTempCheck,
 		fileNode)
 }
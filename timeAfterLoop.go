@@ -0,0 +1,53 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	register("timeAfterLoop",
+		"this tests for time.After in select statements inside loops",
+		severityMedium,
+		categoryPerformance,
+		timeAfterLoopCheck,
+		selectStmt)
+}
+
+func timeAfterLoopCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.SelectStmt);
+	if !ok || f.enclosingLoop() == nil {
+		return;
+	}
+	for _, clause := range stmt.Body.List {
+		cc, ok := clause.(*ast.CommClause);
+		if !ok || cc.Comm == nil {
+			continue;
+		}
+		// case <-time.After(d): or case t := <-time.After(d):
+		var recv ast.Expr;
+		switch comm := cc.Comm.(type) {
+		case *ast.ExprStmt:
+			recv = comm.X;
+		case *ast.AssignStmt:
+			if len(comm.Rhs) == 1 {
+				recv = comm.Rhs[0];
+			}
+		}
+		unary, ok := recv.(*ast.UnaryExpr);
+		if !ok || unary.Op != token.ARROW {
+			continue;
+		}
+		call, ok := unary.X.(*ast.CallExpr);
+		if !ok {
+			continue;
+		}
+		if path, name := f.getPkgFunc(call); path == "time" && name == "After" {
+			f.Reportf(call.Pos(), "time.After in a select loop makes a new timer every iteration, reuse a time.Timer or time.Ticker: %s", f.ASTString(call));
+		}
+	}
+	return;
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("timeCompare",
+		"this tests for time.Time values compared with == or != instead of Equal",
+		severityMedium,
+		categoryCorrectness,
+		timeCompareCheck,
+		binaryExpr)
+}
+
+// isTime checks for the time.Time type
+func isTime(t types.Type) bool {
+	return t != nil && t.String() == "time.Time";
+}
+
+// isZeroTime checks for the time.Time{} literal
+func isZeroTime(f *File, x ast.Expr) bool {
+	lit, ok := ast.Unparen(x).(*ast.CompositeLit);
+	return ok && len(lit.Elts) == 0 && isTime(f.pkg.info.TypeOf(lit));
+}
+
+func timeCompareCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return;
+	}
+	if !isTime(f.pkg.info.TypeOf(expr.X)) || !isTime(f.pkg.info.TypeOf(expr.Y)) {
+		return;
+	}
+	not := "";
+	if expr.Op == token.NEQ {
+		not = "!";
+	}
+	if isZeroTime(f, expr.X) || isZeroTime(f, expr.Y) {
+		t := expr.X;
+		if isZeroTime(f, t) {
+			t = expr.Y;
+		}
+		f.Reportf(expr.Pos(), "time.Time compared to time.Time{} with %s, use %s%s.IsZero(): %s", expr.Op, not, f.ASTString(t), f.ASTString(expr));
+		return;
+	}
+	f.Reportf(expr.Pos(), "time.Time compared with %s, which also compares the location and monotonic clock, use %s%s.Equal(%s)", expr.Op, not, f.ASTString(expr.X), f.ASTString(expr.Y));
+	return;
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register("tmpPath",
+		"this tests for hardcoded paths in /tmp which are predictable and not portable",
+		severityMedium,
+		categorySecurity,
+		tmpPathCheck,
+		basicLit)
+}
+
+func tmpPathCheck(f *File, node ast.Node) {
+	lit, ok := node.(*ast.BasicLit);
+	if !ok || lit.Kind != token.STRING {
+		return;
+	}
+	value, err := strconv.Unquote(lit.Value);
+	if err != nil {
+		return;
+	}
+	if strings.HasPrefix(value, "/tmp/") || strings.HasPrefix(value, "/var/tmp/") {
+		f.Reportf(lit.Pos(), "hardcoded temporary path %s, use os.CreateTemp or os.MkdirTemp", lit.Value);
+	}
+	return;
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("typeAssert",
+		"this tests for type assertions that panic on failure",
+		severityMedium,
+		categoryCorrectness,
+		typeAssertCheck,
+		typeAssertExpr)
+}
+
+// commaOk checks if a type assertion is the only value assigned
+// to two variables, i.e. v, ok := x.(T) or var v, ok = x.(T)
+func commaOk(parent ast.Node, x ast.Expr) bool {
+	switch p := parent.(type) {
+	case *ast.AssignStmt:
+		return len(p.Lhs) == 2 && len(p.Rhs) == 1 && p.Rhs[0] == x;
+	case *ast.ValueSpec:
+		return len(p.Names) == 2 && len(p.Values) == 1 && p.Values[0] == x;
+	}
+	return false;
+}
+
+func typeAssertCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.TypeAssertExpr);
+	if !ok {
+		return;
+	}
+	// x.(type) only appears in type switches
+	if expr.Type == nil {
+		return;
+	}
+	if commaOk(f.parent(), expr) {
+		return;
+	}
+	f.Reportf(expr.Pos(), "type assertion panics on failure, use v, ok := %s", f.ASTString(expr));
+	return;
+}
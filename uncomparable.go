@@ -0,0 +1,55 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("uncomparable",
+		"this tests for slices, maps, funcs and structs holding them compared with == or != to anything but nil",
+		severityLow,
+		categoryCorrectness,
+		uncomparableCheck,
+		binaryExpr)
+}
+
+// comparisonHint suggests what to use instead of == for an uncomparable type
+func comparisonHint(t types.Type) string {
+	switch t.Underlying().(type) {
+	case *types.Slice:
+		return "slices.Equal or bytes.Equal";
+	case *types.Map:
+		return "maps.Equal";
+	case *types.Signature:
+		return "a nil check, functions can only be compared to nil";
+	}
+	return "a field by field comparison or reflect.DeepEqual";
+}
+
+func uncomparableCheck(f *File, node ast.Node) {
+	expr, ok := node.(*ast.BinaryExpr);
+	if !ok || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return;
+	}
+	if isNil(expr.X) || isNil(expr.Y) {
+		return;
+	}
+	for _, x := range []ast.Expr{expr.X, expr.Y} {
+		t := f.pkg.info.TypeOf(x);
+		if t == nil || types.Comparable(t) {
+			continue;
+		}
+		// interfaces are comparable, type parameters are left to the compiler
+		if _, ok := t.(*types.TypeParam); ok {
+			return;
+		}
+		f.Reportf(expr.Pos(), "%s is not comparable with %s, use %s: %s", f.ASTString(x), expr.Op, comparisonHint(t), f.ASTString(expr));
+		return;
+	}
+	return;
+}
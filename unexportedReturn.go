@@ -0,0 +1,59 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/types"
+)
+
+var unexportedReturns = flag.Bool("unexported-returns", false, "report exported functions returning unexported types")
+
+func init() {
+	registerOptIn("unexportedReturn",
+		"this tests for exported functions returning unexported types callers cannot name",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *unexportedReturns;
+		},
+		unexportedReturnCheck,
+		funcDecl)
+}
+
+// exportedFunc checks if a function or method can be called from another package
+func exportedFunc(f *File, fun *ast.FuncDecl) bool {
+	if !fun.Name.IsExported() {
+		return false;
+	}
+	if fun.Recv == nil || len(fun.Recv.List) == 0 {
+		return true;
+	}
+	t := f.pkg.info.TypeOf(fun.Recv.List[0].Type);
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem();
+	}
+	named, ok := t.(*types.Named);
+	return ok && named.Obj().Exported();
+}
+
+func unexportedReturnCheck(f *File, node ast.Node) {
+	fun, ok := node.(*ast.FuncDecl);
+	if !ok || fun.Type.Results == nil || !exportedFunc(f, fun) {
+		return;
+	}
+	for _, field := range fun.Type.Results.List {
+		t := f.pkg.info.TypeOf(field.Type);
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem();
+		}
+		named, ok := t.(*types.Named);
+		if !ok || named.Obj().Exported() || named.Obj().Pkg() != f.pkg.typePkg {
+			continue;
+		}
+		f.Reportf(field.Pos(), "exported function %s returns unexported type %s, export the type or return an exported interface", fun.Name.Name, named.Obj().Name());
+	}
+	return;
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	register("untypedDecode",
+		"this tests for request data decoded into interface{} values",
+		severityMedium,
+		categorySecurity,
+		untypedDecodeCheck,
+		callExpr)
+}
+
+// untypedTarget checks for decoding into interface{}, map[string]interface{} or []interface{}
+// whose shape is then up to whoever sent the data
+func untypedTarget(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem();
+	}
+	if isEmptyInterface(t) {
+		return true;
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		return isEmptyInterface(u.Elem());
+	case *types.Slice:
+		return isEmptyInterface(u.Elem());
+	}
+	return false;
+}
+
+// fromRequest checks if x is an http request body or derived from one
+// through calls like io.ReadAll(r.Body) and json.NewDecoder(r.Body)
+// or variables assigned from them in the enclosing function
+func fromRequest(f *File, x ast.Expr, depth int) bool {
+	if depth > 3 {
+		return false;
+	}
+	switch x := x.(type) {
+	case *ast.SelectorExpr:
+		if t := f.pkg.info.TypeOf(x.X); t != nil && x.Sel.Name == "Body" {
+			return t.String() == "*net/http.Request";
+		}
+		return fromRequest(f, x.X, depth+1);
+	case *ast.CallExpr:
+		if sel, ok := x.Fun.(*ast.SelectorExpr); ok && fromRequest(f, sel.X, depth+1) {
+			return true;
+		}
+		for _, arg := range x.Args {
+			if fromRequest(f, arg, depth+1) {
+				return true;
+			}
+		}
+	case *ast.Ident:
+		obj := f.pkg.info.ObjectOf(x);
+		fun := f.enclosingFunc();
+		if obj == nil || fun == nil {
+			return false;
+		}
+		found := false;
+		ast.Inspect(fun, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt);
+			if !ok || found {
+				return !found;
+			}
+			for i, lhs := range assign.Lhs {
+				if !refersTo(f, lhs, obj) {
+					continue;
+				}
+				// v, err := call() has a single right hand side
+				rhs := assign.Rhs[0];
+				if len(assign.Rhs) == len(assign.Lhs) {
+					rhs = assign.Rhs[i];
+				}
+				if fromRequest(f, rhs, depth+1) {
+					found = true;
+				}
+			}
+			return true;
+		});
+		return found;
+	}
+	return false;
+}
+
+// decodeArgs returns the data and target of json.Unmarshal and
+// of Decode on a json or gob Decoder, where the data is the decoder
+func decodeArgs(f *File, call *ast.CallExpr) (data, target ast.Expr) {
+	if path, name := f.getPkgFunc(call); path == "encoding/json" && name == "Unmarshal" && len(call.Args) == 2 {
+		return call.Args[0], call.Args[1];
+	}
+	switch f.getMethod(call) {
+	case "(*encoding/json.Decoder).Decode", "(*encoding/gob.Decoder).Decode":
+		if len(call.Args) == 1 {
+			return call.Fun.(*ast.SelectorExpr).X, call.Args[0];
+		}
+	}
+	return nil, nil;
+}
+
+func untypedDecodeCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	data, target := decodeArgs(f, call);
+	if data == nil {
+		return;
+	}
+	// &v is the usual form, take the type of v
+	if unary, ok := target.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		target = unary.X;
+	}
+	t := f.pkg.info.TypeOf(target);
+	if t == nil || !untypedTarget(t) || !fromRequest(f, data, 0) {
+		return;
+	}
+	f.Reportf(call.Pos(), "request data decoded into %s, decode into a concrete type: %s", t, f.ASTString(call));
+	return;
+}
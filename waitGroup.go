@@ -0,0 +1,28 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+)
+
+func init() {
+	register("waitGroup",
+		"this tests for sync.WaitGroup.Add called inside the goroutine being waited on",
+		severityMedium,
+		categoryCorrectness,
+		waitGroupCheck,
+		callExpr)
+}
+
+func waitGroupCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok || f.getMethod(call) != "(*sync.WaitGroup).Add" {
+		return;
+	}
+	if _, ok := f.funcLitStmt().(*ast.GoStmt); ok {
+		f.Reportf(call.Pos(), "%s inside the goroutine races with Wait, call it before the go statement", f.ASTString(call));
+	}
+	return;
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	watchMode = flag.Bool("watch", false, "re-run the analysis when .go files change until interrupted")
+	watchInterval = flag.Duration("watch-interval", 500*time.Millisecond, "how often -watch polls for changed files")
+)
+
+// snapshot records the size and modification time of the .go files in a package
+// keyed by directory, or by "" when files were named on the command line
+type snapshot map[string]string
+
+// takeSnapshot walks the arguments and fingerprints each package's .go files
+// there is no file notification API in the standard library so the tree is polled
+func takeSnapshot(args []string, dirs bool) snapshot {
+	snap := make(snapshot);
+	stamp := func(key, path string, info os.FileInfo) {
+		snap[key] += fmt.Sprintf("%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano());
+	}
+	if !dirs {
+		for _, name := range args {
+			if info, err := os.Stat(name); err == nil {
+				stamp("", name, info);
+			}
+		}
+		return snap;
+	}
+	for _, root := range args {
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil;
+			}
+			if info.IsDir() {
+				if tooDeep(root, path) {
+					return filepath.SkipDir;
+				}
+				// empty directories still count so new files are noticed
+				snap[path] += "";
+				return nil;
+			}
+			if strings.HasSuffix(path, ".go") {
+				stamp(filepath.Dir(path), path, info);
+			}
+			return nil;
+		});
+	}
+	return snap;
+}
+
+// changed lists the packages that differ between two snapshots
+func (s snapshot) changed(old snapshot) []string {
+	var keys []string
+	for key, stamp := range s {
+		if prev, ok := old[key]; !ok || prev != stamp {
+			keys = append(keys, key);
+		}
+	}
+	for key := range old {
+		if _, ok := s[key]; !ok {
+			keys = append(keys, key);
+		}
+	}
+	sort.Strings(keys);
+	return keys;
+}
+
+// checkKeys checks the packages of snapshot keys, storing each one's findings in byPkg
+// like a walk of overlapping roots each package is only checked once per run
+func checkKeys(args, keys []string, byPkg map[string][]Finding) {
+	analyzed = make(map[string]bool);
+	for _, key := range keys {
+		findings = nil;
+		if key == "" {
+			checkPackage(uniqueFiles(args));
+		} else if _, err := os.Stat(key); err == nil && firstVisit(key) {
+			checkPackageDir(key);
+		}
+		byPkg[key] = findings;
+	}
+}
+
+// watch runs the analysis then re-runs just the changed packages whenever their files change
+// it returns when interrupted
+func watch(args []string, dirs bool, writeFindings func(io.Writer, []Finding) error) {
+	interrupt := make(chan os.Signal, 1);
+	signal.Notify(interrupt, os.Interrupt);
+	defer signal.Stop(interrupt);
+
+	out := os.Stdout;
+	if *outputFormat == "text" {
+		out = os.Stderr;
+	}
+	// findings for each package so one package can be re-run on its own
+	byPkg := make(map[string][]Finding);
+	run := func(keys []string) {
+		checkKeys(args, keys, byPkg);
+		var all []Finding
+		for _, pkgFindings := range byPkg {
+			all = append(all, pkgFindings...);
+		}
+		sortFindings(all);
+		all = preferSpecific(dedupeFindings(all));
+		findings = all;
+		// clear the terminal before redrawing
+		if *outputFormat == "text" {
+			fmt.Fprint(out, "\033[H\033[2J");
+		}
+		if err := writeFindings(out, all); err != nil {
+			warnf("error writing findings: %s", err);
+		}
+	}
+
+	last := takeSnapshot(args, dirs);
+	var keys []string
+	for key := range last {
+		keys = append(keys, key);
+	}
+	sort.Strings(keys);
+	run(keys);
+
+	ticker := time.NewTicker(*watchInterval);
+	defer ticker.Stop();
+	pending := make(map[string]bool);
+	for {
+		select {
+		case <-interrupt:
+			return;
+		case <-ticker.C:
+		}
+		snap := takeSnapshot(args, dirs);
+		keys := snap.changed(last);
+		last = snap;
+		for _, key := range keys {
+			pending[key] = true;
+		}
+		// debounce, wait for a quiet poll so a burst of saves re-runs once
+		if len(keys) != 0 || len(pending) == 0 {
+			continue;
+		}
+		keys = nil;
+		for key := range pending {
+			keys = append(keys, key);
+			delete(pending, key);
+		}
+		sort.Strings(keys);
+		run(keys);
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckKeys checks a -watch run analyzes a package once when overlapping
+// roots name it twice or a file is given twice, and again on the next run
+func TestCheckKeys(t *testing.T) {
+	root := t.TempDir();
+	sub := filepath.Join(root, "sub");
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err);
+	}
+	name := filepath.Join(sub, "sub.go");
+	if err := os.WriteFile(name, []byte("package sub\n\nfunc sub(x int) {\n\tx = x\n}\n"), 0644); err != nil {
+		t.Fatal(err);
+	}
+	saved := analyzed;
+	defer func() {
+		analyzed = saved;
+	}();
+	saveReport(t);
+	for name := range report {
+		report[name] = name == "selfAssign";
+	}
+	setFlag(t, "fmt", "csv");
+	tests := []struct {
+		name	string
+		args	[]string
+		keys	[]string
+	}{
+		{"overlapping roots", []string{root, sub}, []string{sub, root + string(filepath.Separator) + "." + string(filepath.Separator) + "sub"}},
+		{"repeated files", []string{name, sub + string(filepath.Separator) + "." + string(filepath.Separator) + "sub.go"}, []string{""}},
+	}
+	for _, test := range tests {
+		byPkg := make(map[string][]Finding);
+		// the second run is a rerun after a change, which checks the package again
+		for run := 1; run <= 2; run++ {
+			checkKeys(test.args, test.keys, byPkg);
+			count := 0;
+			for _, pkgFindings := range byPkg {
+				count += len(pkgFindings);
+			}
+			if count != 1 {
+				t.Errorf("%s: run %d got %d findings, want the package analyzed once: %v", test.name, run, count, byPkg);
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+)
+
+// minimum work factors for password hashing
+const (
+	minBcryptCost		= 10		// bcrypt.DefaultCost
+	minScryptN		= 1 << 15
+	minArgon2Time		= 2
+	minArgon2Memory		= 19 * 1024	// in KiB
+)
+
+func init() {
+	register("weakKDF",
+		"this tests for bcrypt, scrypt and argon2 called with weak cost parameters",
+		severityMedium,
+		categorySecurity,
+		weakKDFCheck,
+		callExpr)
+}
+
+// constInt returns the value of an integer constant expression
+func constInt(f *File, x ast.Expr) (int64, bool) {
+	if tv, ok := f.pkg.info.Types[x]; ok && tv.Value != nil {
+		return constant.Int64Val(constant.ToInt(tv.Value));
+	}
+	// without type information only plain literals are understood
+	if lit, ok := x.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		n, err := strconv.ParseInt(lit.Value, 0, 64);
+		return n, err == nil;
+	}
+	return 0, false;
+}
+
+// checkCost reports argument i of call if it is a constant below min
+func checkCost(f *File, call *ast.CallExpr, i int, min int64, param string) {
+	if i >= len(call.Args) {
+		return;
+	}
+	if n, ok := constInt(f, call.Args[i]); ok && n < min {
+		f.Reportf(call.Pos(), "weak %s %d, use at least %d: %s", param, n, min, f.ASTString(call));
+	}
+}
+
+func weakKDFCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	path, name := f.getPkgFunc(call);
+	switch path {
+	case "golang.org/x/crypto/bcrypt":
+		if name == "GenerateFromPassword" {
+			checkCost(f, call, 1, minBcryptCost, "bcrypt cost");
+		}
+	case "golang.org/x/crypto/scrypt":
+		if name == "Key" {
+			checkCost(f, call, 2, minScryptN, "scrypt N");
+		}
+	case "golang.org/x/crypto/argon2":
+		if name == "Key" || name == "IDKey" {
+			checkCost(f, call, 2, minArgon2Time, "argon2 time");
+			checkCost(f, call, 3, minArgon2Memory, "argon2 memory");
+		}
+	}
+	return;
+}
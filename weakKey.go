@@ -0,0 +1,88 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// minKeyLen is the shortest acceptable HMAC signing key in bytes
+const minKeyLen = 32
+
+// keys that are guessable whatever their length
+var weakKeys = []string{"secret", "changeme", "password", "key", "test", "jwt"}
+
+func init() {
+	register("weakKey",
+		"this tests for short or guessable hardcoded HMAC and JWT signing keys",
+		severityHigh,
+		categorySecurity,
+		weakKeyCheck,
+		callExpr)
+}
+
+// constString returns the value of a constant string expression
+func constString(f *File, x ast.Expr) (string, bool) {
+	if tv, ok := f.pkg.info.Types[x]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true;
+	}
+	if lit, ok := x.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, err := strconv.Unquote(lit.Value);
+		return s, err == nil;
+	}
+	return "", false;
+}
+
+// literalKey returns the value of a hardcoded key, a constant string
+// or a conversion like []byte("secret")
+func literalKey(f *File, x ast.Expr) (string, bool) {
+	if call, ok := x.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if arr, ok := call.Fun.(*ast.ArrayType); ok && arr.Len == nil {
+			return constString(f, call.Args[0]);
+		}
+	}
+	return constString(f, x);
+}
+
+// signingKey returns the key argument of a call that signs with an HMAC key
+func signingKey(f *File, call *ast.CallExpr) ast.Expr {
+	if path, name := f.getPkgFunc(call); path == "crypto/hmac" && name == "New" && len(call.Args) == 2 {
+		return call.Args[1];
+	}
+	// jwt.Token's SignedString, the jwt package is found by method name
+	// since there are several forks of it
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "SignedString" && len(call.Args) == 1 {
+		return call.Args[0];
+	}
+	return nil;
+}
+
+func weakKeyCheck(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr);
+	if !ok {
+		return;
+	}
+	arg := signingKey(f, call);
+	if arg == nil {
+		return;
+	}
+	key, ok := literalKey(f, arg);
+	if !ok {
+		return;
+	}
+	for _, weak := range weakKeys {
+		if strings.EqualFold(key, weak) {
+			f.Reportf(arg.Pos(), "guessable hardcoded signing key %s", f.ASTString(arg));
+			return;
+		}
+	}
+	if len(key) < minKeyLen {
+		f.Reportf(arg.Pos(), "hardcoded signing key is %d bytes, use a secret of at least %d bytes: %s", len(key), minKeyLen, f.ASTString(arg));
+	}
+	return;
+}
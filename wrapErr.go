@@ -0,0 +1,99 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+var wrapErrors = flag.Bool("wrap-errors", false, "report exported functions returning other packages' errors without wrapping them")
+
+func init() {
+	registerOptIn("wrapErr",
+		"this tests for exported functions returning errors from other packages without wrapping them",
+		severityLow,
+		categoryStyle,
+		func() bool {
+			return *wrapErrors;
+		},
+		wrapErrCheck,
+		returnStmt)
+}
+
+// foreignCall checks if call is to a function or method from another package
+// errors and fmt are left out since they are how errors get made and wrapped
+func foreignCall(f *File, call *ast.CallExpr) bool {
+	var obj types.Object;
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.SelectorExpr:
+		obj = f.pkg.info.ObjectOf(fun.Sel);
+	case *ast.Ident:
+		obj = f.pkg.info.ObjectOf(fun);
+	}
+	if _, ok := obj.(*types.Func); !ok || obj.Pkg() == nil {
+		return false;
+	}
+	switch obj.Pkg().Path() {
+	case f.pkg.typePkg.Path(), "errors", "fmt":
+		return false;
+	}
+	return true;
+}
+
+// errSource returns the call that last set obj before pos in body, or nil
+func errSource(f *File, body *ast.BlockStmt, obj types.Object, pos token.Pos) *ast.CallExpr {
+	var source *ast.CallExpr;
+	ast.Inspect(body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt);
+		if !ok || assign.Pos() >= pos {
+			return node == nil || node.Pos() < pos;
+		}
+		for i, lhs := range assign.Lhs {
+			if !refersTo(f, lhs, obj) {
+				continue;
+			}
+			source = nil;
+			// v, err := call() has a single right hand side
+			rhs := assign.Rhs[0];
+			if len(assign.Rhs) == len(assign.Lhs) {
+				rhs = assign.Rhs[i];
+			}
+			if call, ok := rhs.(*ast.CallExpr); ok {
+				source = call;
+			}
+		}
+		return true;
+	})
+	return source;
+}
+
+func wrapErrCheck(f *File, node ast.Node) {
+	ret, ok := node.(*ast.ReturnStmt);
+	if !ok {
+		return;
+	}
+	fun, ok := f.enclosingFunc().(*ast.FuncDecl);
+	if !ok || fun.Body == nil || !exportedFunc(f, fun) {
+		return;
+	}
+	for _, result := range ret.Results {
+		id, ok := result.(*ast.Ident);
+		if !ok || !isError(f.pkg.info.TypeOf(id)) {
+			continue;
+		}
+		obj := f.pkg.info.ObjectOf(id);
+		if obj == nil {
+			continue;
+		}
+		call := errSource(f, fun.Body, obj, ret.Pos());
+		if call == nil || !foreignCall(f, call) {
+			continue;
+		}
+		f.Reportf(id.Pos(), "%s from %s returned without context, wrap it with fmt.Errorf(\"...: %%w\", %s)", id.Name, f.ASTString(call.Fun), id.Name);
+	}
+	return;
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Terence Tarvis.  All rights reserved.
+//
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// writers whose Write never fails or reports errors later, i.e. on Flush
+var safeWriters = map[string]bool{
+	"bytes.Buffer":		true,
+	"strings.Builder":	true,
+	"bufio.Writer":		true,
+}
+
+func init() {
+	register("writeCheck",
+		"this tests for Write and WriteString calls on an io.Writer with both results dropped",
+		severityMedium,
+		categoryCorrectness,
+		writeCheck,
+		exprStmt)
+}
+
+// isWriteMethod checks for the io.Writer and io.StringWriter method signatures
+// Write(p []byte) (n int, err error) and WriteString(s string) (n int, err error)
+func isWriteMethod(sig *types.Signature) bool {
+	if sig.Params().Len() != 1 || sig.Results().Len() != 2 {
+		return false;
+	}
+	return sig.Results().At(0).Type().String() == "int" && isError(sig.Results().At(1).Type());
+}
+
+// droppedWrite checks if call is a Write or WriteString on an io.Writer
+// that can fail, for a statement dropping both results
+func droppedWrite(f *File, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr);
+	if !ok || (sel.Sel.Name != "Write" && sel.Sel.Name != "WriteString") {
+		return false;
+	}
+	selection, ok := f.pkg.info.Selections[sel];
+	if !ok || selection.Kind() != types.MethodVal {
+		return false;
+	}
+	sig, ok := selection.Type().(*types.Signature);
+	if !ok || !isWriteMethod(sig) {
+		return false;
+	}
+	return !safeWriters[strings.TrimPrefix(selection.Recv().String(), "*")];
+}
+
+func writeCheck(f *File, node ast.Node) {
+	stmt, ok := node.(*ast.ExprStmt);
+	if !ok {
+		return;
+	}
+	call, ok := stmt.X.(*ast.CallExpr);
+	if !ok || !droppedWrite(f, call) {
+		return;
+	}
+	f.Reportf(stmt.Pos(), "short or failed write goes unnoticed, check the results of %s", f.ASTString(call));
+	return;
+}